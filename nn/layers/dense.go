@@ -74,3 +74,19 @@ func (d *Dense) Forward(inputs *tensor.Tensor[float64]) *tensor.Tensor[float64]
 	output := tensor.MatrixMultiplication(inputs, d.weights).Add(d.biases)
 	return output
 }
+
+// Like Forward(), but for sparse inputs of shape numSamples x numInputs, for example bag-of-words vectors.
+func (d *Dense) ForwardSparse(inputs *tensor.SparseTensor[float64]) *tensor.Tensor[float64] {
+	output := tensor.SparseMatMul(inputs, d.weights).Add(d.biases)
+	return output
+}
+
+// Returns the weights of the layer. Its shape is numInputs x numNeurons.
+func (d *Dense) Weights() *tensor.Tensor[float64] {
+	return d.weights
+}
+
+// Returns the biases of the layer. Its shape is 1 x numNeurons.
+func (d *Dense) Biases() *tensor.Tensor[float64] {
+	return d.biases
+}
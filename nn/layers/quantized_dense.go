@@ -0,0 +1,32 @@
+package layers
+
+import "github.com/biraj21/nnfs-go/tensor"
+
+// A Dense layer with int8 weights for inference, using a quarter of the memory of float32 weights. The inputs are
+// quantized to uint8 on the fly & the biases are kept as is.
+type QuantizedDense struct {
+	// shape: numInputs x numNeurons, quantized per neuron
+	weights *tensor.QuantizedTensor[int8]
+
+	// shape: 1 x numNeurons
+	biases *tensor.Tensor[float64]
+}
+
+// Quantizes the weights of a trained Dense layer.
+func QuantizeDense(d *Dense) QuantizedDense {
+	return QuantizedDense{
+		weights: tensor.QuantizePerChannel[int8](d.weights, 1),
+		biases:  d.biases.Copy(),
+	}
+}
+
+func (d *QuantizedDense) Forward(inputs *tensor.Tensor[float64]) *tensor.Tensor[float64] {
+	quantizedInputs := tensor.Quantize[uint8](inputs)
+	output := tensor.QuantizedMatMul(quantizedInputs, d.weights).Add(d.biases)
+	return output
+}
+
+// Returns the quantized weights of the layer. Its shape is numInputs x numNeurons.
+func (d *QuantizedDense) Weights() *tensor.QuantizedTensor[int8] {
+	return d.weights
+}
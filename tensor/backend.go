@@ -0,0 +1,210 @@
+package tensor
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Elementwise operation computed by a Backend.
+type ElementwiseOp int
+
+const (
+	OpAdd ElementwiseOp = iota
+	OpSubtract
+	OpMultiply
+	OpDivide
+)
+
+func (op ElementwiseOp) String() string {
+	switch op {
+	case OpAdd:
+		return "Add"
+	case OpSubtract:
+		return "Subtract"
+	case OpMultiply:
+		return "Multiply"
+	case OpDivide:
+		return "Divide"
+	default:
+		return fmt.Sprintf("ElementwiseOp(%d)", int(op))
+	}
+}
+
+// Reduction computed by a Backend.
+type ReduceOp int
+
+const (
+	ReduceSum ReduceOp = iota
+	ReduceProd
+	ReduceMax
+	ReduceMin
+)
+
+func (op ReduceOp) String() string {
+	switch op {
+	case ReduceSum:
+		return "Sum"
+	case ReduceProd:
+		return "Prod"
+	case ReduceMax:
+		return "Max"
+	case ReduceMin:
+		return "Min"
+	default:
+		return fmt.Sprintf("ReduceOp(%d)", int(op))
+	}
+}
+
+// Backend computes the kernels that tensor operations dispatch to. The operations validate their arguments & allocate
+// the results before calling it, so kernels only compute. All the slices hold elements in row-major order.
+//
+// ReferenceBackend is used unless another one is registered with RegisterBackend(). Embedding ReferenceBackend in a
+// backend lets it override only some of the kernels.
+type Backend[T Scalar] interface {
+	// Computes result = a x b, where a is m x k, b is k x n & result is m x n. result is zeroed.
+	MatMul(a, b, result []T, m, k, n int)
+
+	// Computes dst[i] = x[i] op y[i]. The slices have the same length.
+	Elementwise(op ElementwiseOp, dst, x, y []T)
+
+	// Reduces src, viewed as an outer x size x inner array, along its middle axis into dst of outer x inner elements.
+	Reduce(op ReduceOp, dst, src []T, outer, size, inner int)
+
+	// Performs the convolution described by Conv(). The arguments are already validated.
+	Conv(input, weight *Tensor[T], opts ConvOptions) *Tensor[T]
+}
+
+// The backends registered with RegisterBackend(), by their element type.
+var backends sync.Map
+
+// Sets the backend used by the operations on tensors of type T & returns the previous one. Passing nil restores
+// ReferenceBackend.
+//
+// For example, a test can record the kernels that are called with a backend that embeds ReferenceBackend[float64].
+func RegisterBackend[T Scalar](backend Backend[T]) (previous Backend[T]) {
+	var zero T
+	key := reflect.TypeOf(zero)
+
+	previous = GetBackend[T]()
+	if backend == nil {
+		backends.Delete(key)
+	} else {
+		backends.Store(key, backend)
+	}
+
+	return previous
+}
+
+// Returns the backend used by the operations on tensors of type T.
+func GetBackend[T Scalar]() Backend[T] {
+	var zero T
+	if backend, ok := backends.Load(reflect.TypeOf(zero)); ok {
+		return backend.(Backend[T])
+	}
+
+	return ReferenceBackend[T]{}
+}
+
+// ReferenceBackend is the default Backend, written in plain Go except for the float32 & float64 kernels that use SIMD
+// instructions where available.
+type ReferenceBackend[T Scalar] struct{}
+
+func (ReferenceBackend[T]) MatMul(a, b, result []T, m, k, n int) {
+	if matMulKernel(a, b, result, k, n) {
+		return
+	}
+
+	parallelFor(m, minItemsPerChunk(k*n), func(start, end int) {
+		for r := start; r < end; r++ {
+			for c := 0; c < n; c++ {
+				sumOfProducts := T(0)
+				for i := 0; i < k; i++ {
+					sumOfProducts += a[r*k+i] * b[i*n+c]
+				}
+
+				result[r*n+c] = sumOfProducts
+			}
+		}
+	})
+}
+
+func (ReferenceBackend[T]) Elementwise(op ElementwiseOp, dst, x, y []T) {
+	parallelFor(len(dst), minItemsPerChunk(1), func(start, end int) {
+		elementwiseChunk(op, dst[start:end], x[start:end], y[start:end])
+	})
+}
+
+func elementwiseChunk[T Scalar](op ElementwiseOp, dst, x, y []T) {
+	if elementwiseKernel(op, dst, x, y) {
+		return
+	}
+
+	switch op {
+	case OpAdd:
+		for i := range dst {
+			dst[i] = x[i] + y[i]
+		}
+	case OpSubtract:
+		for i := range dst {
+			dst[i] = x[i] - y[i]
+		}
+	case OpMultiply:
+		for i := range dst {
+			dst[i] = x[i] * y[i]
+		}
+	case OpDivide:
+		for i := range dst {
+			dst[i] = x[i] / y[i]
+		}
+	default:
+		panic(fmt.Sprintf("Unsupported elementwise operation %v", op))
+	}
+}
+
+func (ReferenceBackend[T]) Reduce(op ReduceOp, dst, src []T, outer, size, inner int) {
+	var f func(accumulator, value T) T
+	switch op {
+	case ReduceSum:
+		f = func(accumulator, value T) T { return accumulator + value }
+	case ReduceProd:
+		f = func(accumulator, value T) T { return accumulator * value }
+	case ReduceMax:
+		// NaNs propagate, like in NumPy
+		f = func(accumulator, value T) T {
+			if !isNaN(accumulator) && (isNaN(value) || value > accumulator) {
+				return value
+			}
+
+			return accumulator
+		}
+	case ReduceMin:
+		f = func(accumulator, value T) T {
+			if !isNaN(accumulator) && (isNaN(value) || value < accumulator) {
+				return value
+			}
+
+			return accumulator
+		}
+	default:
+		panic(fmt.Sprintf("Unsupported reduction %v", op))
+	}
+
+	// every element of dst is reduced sequentially, so the result doesn't depend on the number of goroutines
+	parallelFor(outer*inner, minItemsPerChunk(size), func(start, end int) {
+		for index := start; index < end; index++ {
+			o, i := index/inner, index%inner
+			first := o*size*inner + i
+			accumulator := src[first]
+			for j := 1; j < size; j++ {
+				accumulator = f(accumulator, src[first+j*inner])
+			}
+
+			dst[index] = accumulator
+		}
+	})
+}
+
+func (ReferenceBackend[T]) Conv(input, weight *Tensor[T], opts ConvOptions) *Tensor[T] {
+	return convReference(input, weight, opts)
+}
@@ -0,0 +1,100 @@
+package tensor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+// A backend that records the kernels that are called & computes them with the reference backend.
+type recordingBackend struct {
+	ReferenceBackend[float64]
+	calls []string
+}
+
+func (b *recordingBackend) MatMul(a, c, result []float64, m, k, n int) {
+	b.calls = append(b.calls, "MatMul")
+	b.ReferenceBackend.MatMul(a, c, result, m, k, n)
+}
+
+func (b *recordingBackend) Elementwise(op ElementwiseOp, dst, x, y []float64) {
+	b.calls = append(b.calls, op.String())
+	b.ReferenceBackend.Elementwise(op, dst, x, y)
+}
+
+func (b *recordingBackend) Reduce(op ReduceOp, dst, src []float64, outer, size, inner int) {
+	b.calls = append(b.calls, op.String())
+	b.ReferenceBackend.Reduce(op, dst, src, outer, size, inner)
+}
+
+func (b *recordingBackend) Conv(input, weight *Tensor[float64], opts ConvOptions) *Tensor[float64] {
+	b.calls = append(b.calls, "Conv")
+	return b.ReferenceBackend.Conv(input, weight, opts)
+}
+
+func TestRegisterBackend(t *testing.T) {
+	backend := &recordingBackend{}
+	previous := RegisterBackend[float64](backend)
+	defer RegisterBackend(previous)
+
+	if _, ok := previous.(ReferenceBackend[float64]); !ok {
+		t.Fatalf("expected the reference backend by default, got %T", previous)
+	}
+
+	matrix := WithValue[float64]([][]float64{{1, 2}, {3, 4}})
+	row := WithValue[float64]([]float64{10, 20})
+
+	sum := Add(matrix, row)
+	expected := WithValue[float64]([][]float64{{11, 22}, {13, 24}})
+	if !reflect.DeepEqual(expected, sum) {
+		t.Fatalf("Add(): expected %v, got %v", expected, sum)
+	}
+
+	MatrixMultiplication(matrix, matrix)
+	Sum(matrix, 0)
+	Conv(matrix.Reshape(1, 1, 2, 2), WithShape[float64]([]uint{1, 1, 1, 1}, 2), ConvOptions{})
+
+	// other types are not affected
+	Add(WithValue[int]([]int{1}), WithValue[int]([]int{2}))
+
+	expectedCalls := []string{"Add", "MatMul", "Sum", "Conv", "MatMul"}
+	if !reflect.DeepEqual(expectedCalls, backend.calls) {
+		t.Fatalf("expected calls %v, got %v", expectedCalls, backend.calls)
+	}
+
+	RegisterBackend[float64](nil)
+	if _, ok := GetBackend[float64]().(ReferenceBackend[float64]); !ok {
+		t.Fatalf("expected the reference backend after registering nil, got %T", GetBackend[float64]())
+	}
+}
+
+func TestReductions(t *testing.T) {
+	tensor := WithValue[int]([][][]int{
+		{{1, 2}, {3, 4}, {5, 6}},
+		{{-1, 0}, {7, -8}, {2, 2}},
+	})
+
+	testCases := []struct {
+		name     string
+		result   *Tensor[int]
+		expected *Tensor[int]
+	}{
+		{"Sum(0)", Sum(tensor, 0), WithValue[int]([][]int{{0, 2}, {10, -4}, {7, 8}})},
+		{"Sum(1)", Sum(tensor, 1), WithValue[int]([][]int{{9, 12}, {8, -6}})},
+		{"Prod(-1)", Prod(tensor, -1), WithValue[int]([][]int{{2, 12, 30}, {0, -56, 4}})},
+		{"Max(1)", Max(tensor, 1), WithValue[int]([][]int{{5, 6}, {7, 2}})},
+		{"Min(2)", Min(tensor, 2), WithValue[int]([][]int{{1, 3, 5}, {-1, -8, 2}})},
+	}
+
+	for _, tc := range testCases {
+		if !reflect.DeepEqual(tc.expected, tc.result) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, tc.result)
+		}
+	}
+
+	withNaN := WithValue[float64]([][]float64{{1, math.NaN()}, {3, 2}})
+	maxima := Max(withNaN, 1)
+	if !math.IsNaN(maxima.Get(0)) || maxima.Get(1) != 3 {
+		t.Errorf("Max(): expected [NaN 3], got %v", maxima)
+	}
+}
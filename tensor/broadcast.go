@@ -15,15 +15,20 @@ func (b *BroadcastTensor[T]) Shape() []uint {
 	return b.shape
 }
 
+// Returns the element at the indices of the broadcast shape. Like with Tensor.Get(), negative indices count from the end
+// & out of bounds indices panic.
 func (b *BroadcastTensor[T]) Get(indices ...int) T {
 	if len(indices) != len(b.shape) {
 		panic(fmt.Sprintf("Invalid number of indices %d for broadcast of shape %v", len(indices), b.shape))
 	}
 
-	tensorIndices := make([]int, len(b.tensor.shape))
+	normalized := make([]int, len(indices))
+	for i, index := range indices {
+		normalized[i] = normalizeIndex(index, i, b.shape)
+	}
 
 	// skip extra indices. for eg, broadcast has 5 dims & actual tensor has just 3, then skip the first 2 indices
-	copy(tensorIndices, indices[len(indices)-len(b.tensor.shape):])
+	tensorIndices := normalized[len(indices)-len(b.tensor.shape):]
 
 	// make remaining indices compatible with the tensor's shape
 	for i, dimSize := range b.tensor.shape {
@@ -32,7 +37,7 @@ func (b *BroadcastTensor[T]) Get(indices ...int) T {
 		}
 	}
 
-	return b.tensor.Get(tensorIndices...)
+	return b.tensor.UnsafeGet(tensorIndices...)
 }
 
 func (b *BroadcastTensor[T]) dataIndexToIndices(dataIndex int) []int {
@@ -59,7 +64,7 @@ func (b *BroadcastTensor[T]) ToTensor() *Tensor[T] {
 	t := WithShape[T](b.shape)
 
 	for _, indices := range getAllIndices(b.shape) {
-		t.Set(indices, b.Get(indices...))
+		t.UnsafeSet(indices, b.Get(indices...))
 	}
 
 	return t
@@ -88,29 +93,15 @@ func Broadcast[T Scalar](tensors ...*Tensor[T]) []*BroadcastTensor[T] {
 		return []*BroadcastTensor[T]{}
 	}
 
-	if !CanBroadcast(tensors...) {
-		panic(ErrorCannotBroadcast)
-	}
-
-	maxDimensions := 0
-	for _, t := range tensors {
-		if len(t.shape) > maxDimensions {
-			maxDimensions = len(t.shape)
-		}
-	}
-
-	broadcastShape := make([]uint, maxDimensions)
-	for i := 0; i < maxDimensions; i++ {
-		broadcastShape[i] = 0
+	shapes := make([][]uint, len(tensors))
+	for i, t := range tensors {
+		shapes[i] = t.shape
 	}
 
-	for _, t := range tensors {
-		for j, size := range t.shape {
-			i := maxDimensions - len(t.shape) + j
-			if size > broadcastShape[i] {
-				broadcastShape[i] = size
-			}
-		}
+	// every dimension is either 1 or the same size in all the tensors that don't have 1, which can be 0
+	broadcastShape, ok := broadcastShapes(shapes...)
+	if !ok {
+		panic(ErrorCannotBroadcast)
 	}
 
 	broadcast := make([]*BroadcastTensor[T], len(tensors))
@@ -0,0 +1,106 @@
+package tensor
+
+import (
+	"math"
+	"reflect"
+)
+
+// Tolerance used to decide if two values are close, like in numpy.isclose(). Two values a & b are close if
+// |a - b| <= ATol + RTol * |b|.
+type Tolerance struct {
+	// Relative tolerance.
+	RTol float64
+
+	// Absolute tolerance.
+	ATol float64
+
+	// If true, NaNs are considered equal to each other.
+	EqualNaN bool
+}
+
+// The tolerance used by default, same as NumPy's.
+var DefaultTolerance = Tolerance{RTol: 1e-5, ATol: 1e-8}
+
+// Checks if two tensors have the same shape & elements. NaNs are never equal.
+func Equal[T Scalar](t1, t2 *Tensor[T]) bool {
+	if isHalf[T]() {
+		return Equal(Cast[float32](t1), Cast[float32](t2))
+	}
+
+	if !reflect.DeepEqual(t1.shape, t2.shape) {
+		return false
+	}
+
+	data2 := t2.rowMajor().data
+	for i, value := range t1.rowMajor().data {
+		if value != data2[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns a tensor with 1 where the elements of the two (broadcast) tensors are close within the tolerance, and 0
+// elsewhere. DefaultTolerance is used if none is given.
+func IsClose[T Scalar](t1, t2 *Tensor[T], tolerance ...Tolerance) *Tensor[uint8] {
+	tol := getTolerance(tolerance)
+
+	broadcasts := Broadcast(t1, t2)
+	b1 := broadcasts[0]
+	b2 := broadcasts[1]
+
+	result := WithShape[uint8](b1.shape)
+	numElements := int(countElementsFromShape(result.shape))
+	for i := 0; i < numElements; i++ {
+		if isCloseScalar(toFloat64(b1.FlattenedGet(i)), toFloat64(b2.FlattenedGet(i)), tol) {
+			result.data[i] = 1
+		}
+	}
+
+	return result
+}
+
+// Checks if all the elements of the two (broadcast) tensors are close within the tolerance. DefaultTolerance is used
+// if none is given.
+func AllClose[T Scalar](t1, t2 *Tensor[T], tolerance ...Tolerance) bool {
+	tol := getTolerance(tolerance)
+
+	broadcasts := Broadcast(t1, t2)
+	b1 := broadcasts[0]
+	b2 := broadcasts[1]
+
+	numElements := int(countElementsFromShape(b1.shape))
+	for i := 0; i < numElements; i++ {
+		if !isCloseScalar(toFloat64(b1.FlattenedGet(i)), toFloat64(b2.FlattenedGet(i)), tol) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getTolerance(tolerance []Tolerance) Tolerance {
+	if len(tolerance) > 1 {
+		panic("Only one tolerance is allowed!")
+	}
+
+	if len(tolerance) == 0 {
+		return DefaultTolerance
+	}
+
+	return tolerance[0]
+}
+
+func isCloseScalar(a, b float64, tol Tolerance) bool {
+	if math.IsNaN(a) || math.IsNaN(b) {
+		return tol.EqualNaN && math.IsNaN(a) && math.IsNaN(b)
+	}
+
+	// infinities are only close to themselves
+	if math.IsInf(a, 0) || math.IsInf(b, 0) {
+		return a == b
+	}
+
+	return math.Abs(a-b) <= tol.ATol+tol.RTol*math.Abs(b)
+}
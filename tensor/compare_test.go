@@ -0,0 +1,59 @@
+package tensor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestEqual(t *testing.T) {
+	a := WithValue[int]([][]int{{1, 2}, {3, 4}})
+
+	if !Equal(a, a.Copy()) {
+		t.Fatalf("Equal(): expected true for a copy")
+	}
+
+	if Equal(a, a.Reshape(4)) {
+		t.Fatalf("Equal(): expected false for different shapes")
+	}
+
+	if !Equal(a, a.AsFortran()) {
+		t.Fatalf("Equal(): expected true regardless of the layout")
+	}
+
+	if !Equal(WithValue[int](5), WithValue[int](5)) || Equal(WithValue[int](5), WithValue[int](6)) {
+		t.Fatalf("Equal(): wrong result for 0D tensors")
+	}
+}
+
+func TestIsClose(t *testing.T) {
+	nan := math.NaN()
+	a := WithValue[float64]([]float64{1, 1 + 1e-9, nan, math.Inf(1), 1.1})
+	b := WithValue[float64]([]float64{1, 1, nan, math.Inf(1), 1})
+
+	expected := WithValue[uint8]([]uint8{1, 1, 0, 1, 0})
+	result := IsClose(a, b)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[uint8]([]uint8{1, 1, 1, 1, 1})
+	result = IsClose(a, b, Tolerance{RTol: 0.2, EqualNaN: true})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestAllClose(t *testing.T) {
+	a := WithValue[float32]([][]float32{{0.1 + 0.2, 1}, {2, 3}})
+	b := WithValue[float32]([][]float32{{0.3, 1}, {2, 3}})
+
+	if !AllClose(a, b) {
+		t.Fatalf("AllClose(): expected true")
+	}
+
+	// broadcasting
+	if AllClose(a, WithValue[float32]([]float32{0.3, 1})) {
+		t.Fatalf("AllClose(): expected false")
+	}
+}
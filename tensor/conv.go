@@ -0,0 +1,449 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Options for Conv() & ConvBackward(). Stride, Padding & Dilation can have one value per spatial dimension, a single
+// value used for all of them, or be nil for the defaults.
+type ConvOptions struct {
+	// Step between windows. Defaults to 1.
+	Stride []int
+
+	// Number of zeros added on both sides of every spatial dimension. Defaults to 0.
+	Padding []int
+
+	// Spacing between kernel elements. Defaults to 1.
+	Dilation []int
+
+	// Number of groups the input & output channels are split into. Each group of output channels only sees the
+	// corresponding group of input channels. Defaults to 1.
+	Groups int
+}
+
+// Options for the pooling functions. Stride & Padding can have one value per spatial dimension, a single value used
+// for all of them, or be nil for the defaults.
+type PoolOptions struct {
+	// Size of the pooling window. Required.
+	KernelSize []uint
+
+	// Step between windows. Defaults to the kernel size.
+	Stride []int
+
+	// Number of padding elements added on both sides of every spatial dimension. Defaults to 0. Padding is ignored by
+	// max pooling & counted as zeros by average pooling.
+	Padding []int
+}
+
+// Describes how the windows of a convolution or pooling map onto an input with a single channel.
+type convGeometry struct {
+	inputShape  []uint
+	kernelShape []uint
+	outputShape []uint
+
+	numInput  int
+	numKernel int
+	numOutput int
+
+	// offsets[o*numKernel + k] is the flat index into the input of the element at kernel position k of the window for
+	// output position o, or -1 if it falls in the padding
+	offsets []int
+}
+
+func newConvGeometry(inputShape, kernelShape []uint, stride, padding, dilation []int) *convGeometry {
+	numDims := len(inputShape)
+	stride = spatialParam("stride", stride, numDims, 1)
+	padding = spatialParam("padding", padding, numDims, 0)
+	dilation = spatialParam("dilation", dilation, numDims, 1)
+
+	outputShape := make([]uint, numDims)
+	for i := range outputShape {
+		if stride[i] < 1 || dilation[i] < 1 || padding[i] < 0 {
+			panic(fmt.Sprintf("Invalid stride %v, padding %v or dilation %v", stride, padding, dilation))
+		}
+
+		span := dilation[i]*(int(kernelShape[i])-1) + 1
+		padded := int(inputShape[i]) + 2*padding[i]
+		if span > padded {
+			panic(fmt.Sprintf("Kernel of shape %v (dilation %v) is larger than the padded input of shape %v", kernelShape, dilation, inputShape))
+		}
+
+		outputShape[i] = uint((padded-span)/stride[i] + 1)
+	}
+
+	g := &convGeometry{
+		inputShape:  inputShape,
+		kernelShape: kernelShape,
+		outputShape: outputShape,
+		numInput:    int(countElementsFromShape(inputShape)),
+		numKernel:   int(countElementsFromShape(kernelShape)),
+		numOutput:   int(countElementsFromShape(outputShape)),
+	}
+
+	inputStrides := calculateStrides(inputShape)
+	kernelIndices := getAllIndices(kernelShape)
+
+	g.offsets = make([]int, g.numOutput*g.numKernel)
+	for o, outputIndices := range getAllIndices(outputShape) {
+		for k, kernelIndices := range kernelIndices {
+			offset := 0
+			for d := 0; d < numDims && offset >= 0; d++ {
+				position := outputIndices[d]*stride[d] - padding[d] + kernelIndices[d]*dilation[d]
+				if position < 0 || position >= int(inputShape[d]) {
+					offset = -1
+				} else {
+					offset += position * int(inputStrides[d])
+				}
+			}
+
+			g.offsets[o*g.numKernel+k] = offset
+		}
+	}
+
+	return g
+}
+
+// Expands a per-dimension parameter to one value per spatial dimension.
+func spatialParam(name string, values []int, numDims, defaultValue int) []int {
+	switch len(values) {
+	case 0:
+		values = []int{defaultValue}
+		fallthrough
+	case 1:
+		expanded := make([]int, numDims)
+		for i := range expanded {
+			expanded[i] = values[0]
+		}
+
+		return expanded
+	case numDims:
+		return values
+	default:
+		panic(fmt.Sprintf("Expected 1 or %d values for %s, got %v", numDims, name, values))
+	}
+}
+
+// Rearranges the windows of an input of shape (channels, *spatial) into the columns of a matrix of shape
+// (channels * prod(kernelShape), number of windows), so that convolution becomes a matrix multiplication.
+func Im2Col[T Scalar](input *Tensor[T], kernelShape []uint, opts ConvOptions) *Tensor[T] {
+	if input.NDims() != len(kernelShape)+1 {
+		panic(fmt.Sprintf("Im2Col(): expected an input of shape (channels, *spatial) for a kernel of shape %v, got %v", kernelShape, input.shape))
+	}
+
+	g := newConvGeometry(input.shape[1:], kernelShape, opts.Stride, opts.Padding, opts.Dilation)
+	return im2col(input.rowMajor().data, int(input.shape[0]), g)
+}
+
+func im2col[T Scalar](data []T, numChannels int, g *convGeometry) *Tensor[T] {
+	cols := WithShape[T]([]uint{uint(numChannels * g.numKernel), uint(g.numOutput)})
+	for c := 0; c < numChannels; c++ {
+		plane := data[c*g.numInput : (c+1)*g.numInput]
+		for k := 0; k < g.numKernel; k++ {
+			row := cols.data[(c*g.numKernel+k)*g.numOutput:]
+			for o := 0; o < g.numOutput; o++ {
+				if offset := g.offsets[o*g.numKernel+k]; offset >= 0 {
+					row[o] = plane[offset]
+				}
+			}
+		}
+	}
+
+	return cols
+}
+
+// The reverse of Im2Col(). Sums the columns back into a tensor of shape inputShape, i.e. (channels, *spatial).
+// Elements covered by multiple windows receive the sum of all their values, which is what the backward pass of a
+// convolution needs.
+func Col2Im[T Scalar](cols *Tensor[T], inputShape, kernelShape []uint, opts ConvOptions) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](Col2Im(Cast[float32](cols), inputShape, kernelShape, opts))
+	}
+
+	if len(inputShape) != len(kernelShape)+1 {
+		panic(fmt.Sprintf("Col2Im(): expected an input shape (channels, *spatial) for a kernel of shape %v, got %v", kernelShape, inputShape))
+	}
+
+	g := newConvGeometry(inputShape[1:], kernelShape, opts.Stride, opts.Padding, opts.Dilation)
+
+	expectedShape := []uint{inputShape[0] * uint(g.numKernel), uint(g.numOutput)}
+	cols = cols.rowMajor()
+	if !slices.Equal(cols.shape, expectedShape) {
+		panic(fmt.Sprintf("Col2Im(): expected columns of shape %v, got %v", expectedShape, cols.shape))
+	}
+
+	result := WithShape[T](inputShape)
+	col2im(cols.data, result.data, int(inputShape[0]), g)
+	return result
+}
+
+// Adds the columns to data, which has the shape (numChannels, *spatial).
+func col2im[T Scalar](cols, data []T, numChannels int, g *convGeometry) {
+	for c := 0; c < numChannels; c++ {
+		plane := data[c*g.numInput : (c+1)*g.numInput]
+		for k := 0; k < g.numKernel; k++ {
+			row := cols[(c*g.numKernel+k)*g.numOutput:]
+			for o := 0; o < g.numOutput; o++ {
+				if offset := g.offsets[o*g.numKernel+k]; offset >= 0 {
+					plane[offset] += row[o]
+				}
+			}
+		}
+	}
+}
+
+// Validates the shapes of a convolution's input of shape (batch, channels, *spatial) & weight of shape
+// (outChannels, channels / groups, *kernel), and returns the geometry & number of groups.
+func convSetup[T Scalar](name string, input, weight *Tensor[T], opts ConvOptions) (*convGeometry, int) {
+	if input.NDims() < 3 || weight.NDims() != input.NDims() {
+		panic(fmt.Sprintf("%s(): expected an input of shape (batch, channels, *spatial) & a weight of shape (outChannels, channels/groups, *kernel), got %v & %v", name, input.shape, weight.shape))
+	}
+
+	groups := max(opts.Groups, 1)
+	numChannels := int(input.shape[1])
+	numOutChannels := int(weight.shape[0])
+	if numChannels%groups != 0 || numOutChannels%groups != 0 || int(weight.shape[1]) != numChannels/groups {
+		panic(fmt.Sprintf("%s(): input of shape %v & weight of shape %v are incompatible for %d groups", name, input.shape, weight.shape, groups))
+	}
+
+	return newConvGeometry(input.shape[2:], weight.shape[2:], opts.Stride, opts.Padding, opts.Dilation), groups
+}
+
+// Performs an N-dimensional (1D, 2D, 3D, ...) convolution (strictly speaking cross-correlation, like in other
+// frameworks) of an input of shape (batch, channels, *spatial) with a weight of shape
+// (outChannels, channels / groups, *kernel). The result has the shape (batch, outChannels, *outputSpatial).
+//
+// It is implemented with Im2Col() & MatrixMultiplication().
+func Conv[T Scalar](input, weight *Tensor[T], opts ConvOptions) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](Conv(Cast[float32](input), Cast[float32](weight), opts))
+	}
+
+	convSetup("Conv", input, weight, opts)
+	return GetBackend[T]().Conv(input.rowMajor(), weight.rowMajor(), opts)
+}
+
+// The reference implementation of Conv().
+func convReference[T Scalar](input, weight *Tensor[T], opts ConvOptions) *Tensor[T] {
+	g, groups := convSetup("Conv", input, weight, opts)
+
+	batchSize := int(input.shape[0])
+	groupChannels := int(input.shape[1]) / groups
+	groupOutChannels := int(weight.shape[0]) / groups
+	kernelRows := groupChannels * g.numKernel
+
+	result := WithShape[T](slices.Concat([]uint{input.shape[0], weight.shape[0]}, g.outputShape))
+	// every sample & group writes a separate part of the result
+	parallelFor(batchSize*groups, minItemsPerChunk(groupOutChannels*kernelRows*g.numOutput), func(start, end int) {
+		for index := start; index < end; index++ {
+			n, group := index/groups, index%groups
+			inputStart := (n*int(input.shape[1]) + group*groupChannels) * g.numInput
+			cols := im2col(input.data[inputStart:], groupChannels, g)
+
+			weightStart := group * groupOutChannels * kernelRows
+			w := fromData([]uint{uint(groupOutChannels), uint(kernelRows)}, weight.data[weightStart:weightStart+groupOutChannels*kernelRows])
+
+			output := MatrixMultiplication(w, cols)
+
+			resultStart := (n*int(weight.shape[0]) + group*groupOutChannels) * g.numOutput
+			copy(result.data[resultStart:], output.data)
+		}
+	})
+
+	return result
+}
+
+// Computes the gradients of Conv() with respect to its input & weight, given the gradient of its output.
+func ConvBackward[T Scalar](gradOutput, input, weight *Tensor[T], opts ConvOptions) (gradInput, gradWeight *Tensor[T]) {
+	if isHalf[T]() {
+		gradInput, gradWeight := ConvBackward(Cast[float32](gradOutput), Cast[float32](input), Cast[float32](weight), opts)
+		return Cast[T](gradInput), Cast[T](gradWeight)
+	}
+
+	g, groups := convSetup("ConvBackward", input, weight, opts)
+	gradOutput, input, weight = gradOutput.rowMajor(), input.rowMajor(), weight.rowMajor()
+
+	expectedShape := slices.Concat([]uint{input.shape[0], weight.shape[0]}, g.outputShape)
+	if !slices.Equal(gradOutput.shape, expectedShape) {
+		panic(fmt.Sprintf("ConvBackward(): expected an output gradient of shape %v, got %v", expectedShape, gradOutput.shape))
+	}
+
+	batchSize := int(input.shape[0])
+	groupChannels := int(input.shape[1]) / groups
+	groupOutChannels := int(weight.shape[0]) / groups
+	kernelRows := groupChannels * g.numKernel
+
+	gradInput = WithShape[T](input.shape)
+	gradWeight = WithShape[T](weight.shape)
+	for group := 0; group < groups; group++ {
+		weightStart := group * groupOutChannels * kernelRows
+		weightEnd := weightStart + groupOutChannels*kernelRows
+		w := fromData([]uint{uint(groupOutChannels), uint(kernelRows)}, weight.data[weightStart:weightEnd])
+		wT := Transpose(w)
+
+		for n := 0; n < batchSize; n++ {
+			inputStart := (n*int(input.shape[1]) + group*groupChannels) * g.numInput
+			outputStart := (n*int(weight.shape[0]) + group*groupOutChannels) * g.numOutput
+			gradOut := fromData(
+				[]uint{uint(groupOutChannels), uint(g.numOutput)},
+				gradOutput.data[outputStart:outputStart+groupOutChannels*g.numOutput],
+			)
+
+			// dW = dY x cols^T
+			cols := im2col(input.data[inputStart:], groupChannels, g)
+			dW := MatrixMultiplication(gradOut, Transpose(cols))
+			for i, v := range dW.data {
+				gradWeight.data[weightStart+i] += v
+			}
+
+			// dX = col2im(W^T x dY)
+			gradCols := MatrixMultiplication(wT, gradOut)
+			col2im(gradCols.data, gradInput.data[inputStart:], groupChannels, g)
+		}
+	}
+
+	return gradInput, gradWeight
+}
+
+// Validates the input of shape (batch, channels, *spatial) of a pooling function & returns the geometry.
+func poolSetup(name string, inputShape []uint, opts PoolOptions) *convGeometry {
+	if len(inputShape) < 3 || len(opts.KernelSize) != len(inputShape)-2 {
+		panic(fmt.Sprintf("%s(): expected an input of shape (batch, channels, *spatial) & a kernel size per spatial dimension, got %v & %v", name, inputShape, opts.KernelSize))
+	}
+
+	stride := opts.Stride
+	if stride == nil {
+		stride = make([]int, len(opts.KernelSize))
+		for i, size := range opts.KernelSize {
+			stride[i] = int(size)
+		}
+	}
+
+	return newConvGeometry(inputShape[2:], opts.KernelSize, stride, opts.Padding, nil)
+}
+
+func ensurePoolGradientShape[T Scalar](name string, gradOutput *Tensor[T], inputShape []uint, g *convGeometry) {
+	expectedShape := slices.Concat(inputShape[:2], g.outputShape)
+	if !slices.Equal(gradOutput.shape, expectedShape) {
+		panic(fmt.Sprintf("%s(): expected an output gradient of shape %v, got %v", name, expectedShape, gradOutput.shape))
+	}
+}
+
+// Returns the index in the window of output o of the largest element of plane, or -1 if the window is all padding.
+func windowArgMax[T Scalar](plane []T, g *convGeometry, o int) int {
+	best := -1
+	for k := 0; k < g.numKernel; k++ {
+		offset := g.offsets[o*g.numKernel+k]
+		if offset >= 0 && (best < 0 || plane[offset] > plane[best]) {
+			best = offset
+		}
+	}
+
+	return best
+}
+
+// Max pooling over the spatial dimensions of an input of shape (batch, channels, *spatial).
+func MaxPool[T Scalar](input *Tensor[T], opts PoolOptions) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](MaxPool(Cast[float32](input), opts))
+	}
+
+	g := poolSetup("MaxPool", input.shape, opts)
+	input = input.rowMajor()
+
+	numPlanes := int(input.shape[0] * input.shape[1])
+	result := WithShape[T](slices.Concat(input.shape[:2], g.outputShape))
+	for p := 0; p < numPlanes; p++ {
+		plane := input.data[p*g.numInput : (p+1)*g.numInput]
+		for o := 0; o < g.numOutput; o++ {
+			if best := windowArgMax(plane, g, o); best >= 0 {
+				result.data[p*g.numOutput+o] = plane[best]
+			}
+		}
+	}
+
+	return result
+}
+
+// Computes the gradient of MaxPool() with respect to its input. The gradient of every output goes to the largest
+// element of its window.
+func MaxPoolBackward[T Scalar](gradOutput, input *Tensor[T], opts PoolOptions) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](MaxPoolBackward(Cast[float32](gradOutput), Cast[float32](input), opts))
+	}
+
+	g := poolSetup("MaxPoolBackward", input.shape, opts)
+	ensurePoolGradientShape("MaxPoolBackward", gradOutput, input.shape, g)
+	gradOutput, input = gradOutput.rowMajor(), input.rowMajor()
+
+	numPlanes := int(input.shape[0] * input.shape[1])
+	gradInput := WithShape[T](input.shape)
+	for p := 0; p < numPlanes; p++ {
+		plane := input.data[p*g.numInput : (p+1)*g.numInput]
+		gradPlane := gradInput.data[p*g.numInput : (p+1)*g.numInput]
+		for o := 0; o < g.numOutput; o++ {
+			if best := windowArgMax(plane, g, o); best >= 0 {
+				gradPlane[best] += gradOutput.data[p*g.numOutput+o]
+			}
+		}
+	}
+
+	return gradInput
+}
+
+// Average pooling over the spatial dimensions of an input of shape (batch, channels, *spatial). Padding counts as
+// zeros, so every window is divided by the kernel size.
+func AvgPool[T Scalar](input *Tensor[T], opts PoolOptions) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](AvgPool(Cast[float32](input), opts))
+	}
+
+	g := poolSetup("AvgPool", input.shape, opts)
+	input = input.rowMajor()
+
+	numPlanes := int(input.shape[0] * input.shape[1])
+	result := WithShape[T](slices.Concat(input.shape[:2], g.outputShape))
+	for p := 0; p < numPlanes; p++ {
+		plane := input.data[p*g.numInput : (p+1)*g.numInput]
+		for o := 0; o < g.numOutput; o++ {
+			sum := T(0)
+			for k := 0; k < g.numKernel; k++ {
+				if offset := g.offsets[o*g.numKernel+k]; offset >= 0 {
+					sum += plane[offset]
+				}
+			}
+
+			result.data[p*g.numOutput+o] = sum / T(g.numKernel)
+		}
+	}
+
+	return result
+}
+
+// Computes the gradient of AvgPool() with respect to its input of the given shape. The gradient of every output is
+// spread evenly over its window.
+func AvgPoolBackward[T Scalar](gradOutput *Tensor[T], inputShape []uint, opts PoolOptions) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](AvgPoolBackward(Cast[float32](gradOutput), inputShape, opts))
+	}
+
+	g := poolSetup("AvgPoolBackward", inputShape, opts)
+	ensurePoolGradientShape("AvgPoolBackward", gradOutput, inputShape, g)
+	gradOutput = gradOutput.rowMajor()
+
+	numPlanes := int(inputShape[0] * inputShape[1])
+	gradInput := WithShape[T](inputShape)
+	for p := 0; p < numPlanes; p++ {
+		gradPlane := gradInput.data[p*g.numInput : (p+1)*g.numInput]
+		for o := 0; o < g.numOutput; o++ {
+			grad := gradOutput.data[p*g.numOutput+o] / T(g.numKernel)
+			for k := 0; k < g.numKernel; k++ {
+				if offset := g.offsets[o*g.numKernel+k]; offset >= 0 {
+					gradPlane[offset] += grad
+				}
+			}
+		}
+	}
+
+	return gradInput
+}
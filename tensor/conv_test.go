@@ -0,0 +1,144 @@
+package tensor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIm2ColCol2Im(t *testing.T) {
+	input := WithValue[int]([][][]int{{
+		{1, 2, 3},
+		{4, 5, 6},
+		{7, 8, 9},
+	}})
+
+	expected := WithValue[int]([][]int{
+		{1, 2, 4, 5},
+		{2, 3, 5, 6},
+		{4, 5, 7, 8},
+		{5, 6, 8, 9},
+	})
+	cols := Im2Col(input, []uint{2, 2}, ConvOptions{})
+	if !reflect.DeepEqual(expected, cols) {
+		t.Fatalf("expected %v, got %v", expected, cols)
+	}
+
+	// every element is multiplied by the number of windows that cover it
+	expected = WithValue[int]([][][]int{{
+		{1, 4, 3},
+		{8, 20, 12},
+		{7, 16, 9},
+	}})
+	result := Col2Im(cols, input.shape, []uint{2, 2}, ConvOptions{})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestConv1D(t *testing.T) {
+	input := WithValue[int]([][][]int{{{1, 2, 3, 4, 5}}})
+	weight := WithValue[int]([][][]int{{{1, 0, -1}}})
+
+	expected := WithValue[int]([][][]int{{{-2, -2, 4}}})
+	result := Conv(input, weight, ConvOptions{Stride: []int{2}, Padding: []int{1}})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[int]([][][]int{{{-4}}})
+	result = Conv(input, weight, ConvOptions{Dilation: []int{2}})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestConv2DGroups(t *testing.T) {
+	// 2 channels, each convolved with its own kernel
+	input := WithValue[int]([][][][]int{{
+		{{1, 2}, {3, 4}},
+		{{5, 6}, {7, 8}},
+	}})
+	weight := WithValue[int]([][][][]int{
+		{{{1, 1}, {1, 1}}},
+		{{{1, 0}, {0, -1}}},
+	})
+
+	expected := WithValue[int]([][][][]int{{{{10}}, {{-3}}}})
+	result := Conv(input, weight, ConvOptions{Groups: 2})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestConvBackward(t *testing.T) {
+	input := WithRandom[int]([]uint{2, 4, 5, 6}, -5, 5)
+	weight := WithRandom[int]([]uint{6, 2, 3, 2}, -5, 5)
+	opts := ConvOptions{Stride: []int{2, 1}, Padding: []int{1}, Groups: 2}
+
+	output := Conv(input, weight, opts)
+	gradOutput := WithRandom[int](output.shape, -5, 5)
+	gradInput, gradWeight := ConvBackward(gradOutput, input, weight, opts)
+
+	// convolution is linear in both the input & the weight, so <dY, Y> = <dX, X> = <dW, W>
+	dot := func(a, b *Tensor[int]) int {
+		sum := 0
+		for i := range a.data {
+			sum += a.data[i] * b.data[i]
+		}
+
+		return sum
+	}
+
+	expected := dot(gradOutput, output)
+	if result := dot(gradInput, input); result != expected {
+		t.Fatalf("<dX, X>: expected %d, got %d", expected, result)
+	}
+
+	if result := dot(gradWeight, weight); result != expected {
+		t.Fatalf("<dW, W>: expected %d, got %d", expected, result)
+	}
+}
+
+func TestMaxPool(t *testing.T) {
+	input := WithValue[float64]([][][][]float64{{{
+		{1, 5, 2, 0},
+		{3, 4, 8, 1},
+		{0, 2, 3, 3},
+		{9, 1, 4, 2},
+	}}})
+	opts := PoolOptions{KernelSize: []uint{2, 2}}
+
+	expected := WithValue[float64]([][][][]float64{{{{5, 8}, {9, 4}}}})
+	result := MaxPool(input, opts)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expectedGrad := WithValue[float64]([][][][]float64{{{
+		{0, 1, 0, 0},
+		{0, 0, 2, 0},
+		{0, 0, 0, 0},
+		{3, 0, 4, 0},
+	}}})
+	grad := MaxPoolBackward(WithValue[float64]([][][][]float64{{{{1, 2}, {3, 4}}}}), input, opts)
+	if !reflect.DeepEqual(expectedGrad, grad) {
+		t.Fatalf("expected %v, got %v", expectedGrad, grad)
+	}
+}
+
+func TestAvgPool(t *testing.T) {
+	input := WithValue[float64]([][][]float64{{{1, 2, 3, 4, 5, 6}}})
+	opts := PoolOptions{KernelSize: []uint{2}, Stride: []int{2}}
+
+	expected := WithValue[float64]([][][]float64{{{1.5, 3.5, 5.5}}})
+	result := AvgPool(input, opts)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expectedGrad := WithValue[float64]([][][]float64{{{0.5, 0.5, 1, 1, 1.5, 1.5}}})
+	grad := AvgPoolBackward(WithValue[float64]([][][]float64{{{1, 2, 3}}}), input.shape, opts)
+	if !reflect.DeepEqual(expectedGrad, grad) {
+		t.Fatalf("expected %v, got %v", expectedGrad, grad)
+	}
+}
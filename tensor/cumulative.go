@@ -0,0 +1,194 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Number of elements above which cumulative operations use multiple goroutines.
+const parallelScanThreshold = 1 << 15
+
+// Size of the blocks that a long lane is split into for a parallel prefix scan. It is fixed, rather than depending on
+// the number of goroutines, so that the results are always the same.
+const scanBlockSize = 1 << 12
+
+// Returns the cumulative sum of the elements along the axis. Negative axes count from the end.
+func CumSum[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](CumSum(Cast[float32](t), axis))
+	}
+
+	return scan(t, axis, func(a, b T) T { return a + b })
+}
+
+// Returns the cumulative product of the elements along the axis. Negative axes count from the end.
+func CumProd[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](CumProd(Cast[float32](t), axis))
+	}
+
+	return scan(t, axis, func(a, b T) T { return a * b })
+}
+
+// Returns the cumulative maximum of the elements along the axis. Once a NaN is found, the rest of the lane is NaN.
+// Negative axes count from the end.
+func CumMax[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](CumMax(Cast[float32](t), axis))
+	}
+
+	return scan(t, axis, func(a, b T) T {
+		if a != a || a > b {
+			return a
+		}
+
+		return b
+	})
+}
+
+// Returns the cumulative minimum of the elements along the axis. Once a NaN is found, the rest of the lane is NaN.
+// Negative axes count from the end.
+func CumMin[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](CumMin(Cast[float32](t), axis))
+	}
+
+	return scan(t, axis, func(a, b T) T {
+		if a != a || a < b {
+			return a
+		}
+
+		return b
+	})
+}
+
+// Returns log(CumSum(exp(t))) along the axis, computed without overflowing. Negative axes count from the end.
+func LogCumSumExp[T FloatScalar](t *Tensor[T], axis int) *Tensor[T] {
+	return scan(t, axis, func(a, b T) T {
+		x, y := float64(a), float64(b)
+		if math.IsInf(x, -1) {
+			return b
+		}
+
+		if math.IsInf(y, -1) {
+			return a
+		}
+
+		// log(e^x + e^y) = max + log(1 + e^(min - max))
+		larger, smaller := math.Max(x, y), math.Min(x, y)
+		return T(larger + math.Log1p(math.Exp(smaller-larger)))
+	})
+}
+
+// Returns the n-th discrete difference along the axis, i.e. out[i] = t[i+1] - t[i] applied n times. The axis shrinks
+// by n, and is empty if n is at least its size, like with numpy.diff(). Negative axes count from the end.
+func Diff[T Scalar](t *Tensor[T], n int, axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](Diff(Cast[float32](t), n, axis))
+	}
+
+	axis = normalizeAxis(axis, t.NDims())
+	if n < 0 {
+		panic(fmt.Sprintf("Diff(): order must be non-negative, got %d", n))
+	}
+
+	resultShape := slices.Clone(t.shape)
+	resultShape[axis] = uint(max(int(t.shape[axis])-n, 0))
+
+	result := WithShape[T](resultShape)
+	transformLanes(t, result, axis, func(lane, output []T) {
+		for order := 0; order < n; order++ {
+			for i := 0; i < len(lane)-order-1; i++ {
+				lane[i] = lane[i+1] - lane[i]
+			}
+		}
+
+		copy(output, lane)
+	})
+
+	return result
+}
+
+// Computes the inclusive scan of the lanes along the axis with the associative op.
+func scan[T Scalar](t *Tensor[T], axis int, op func(a, b T) T) *Tensor[T] {
+	axis = normalizeAxis(axis, t.NDims())
+
+	result := WithShape[T](slices.Clone(t.shape))
+	size := int(t.shape[axis])
+	stride := int(t.strides[axis])
+	resultStride := int(result.strides[axis])
+	starts := laneStarts(t.shape, t.strides, axis)
+	resultStarts := laneStarts(result.shape, result.strides, axis)
+
+	scanLane := func(i int) {
+		lane := make([]T, size)
+		for j := range lane {
+			lane[j] = t.data[starts[i]+j*stride]
+		}
+
+		if size >= parallelScanThreshold {
+			parallelScan(lane, op)
+		} else {
+			sequentialScan(lane, op)
+		}
+
+		for j, value := range lane {
+			result.data[resultStarts[i]+j*resultStride] = value
+		}
+	}
+
+	// long lanes are scanned in parallel one by one, otherwise the lanes themselves are spread across goroutines
+	minLanesPerChunk := max(parallelScanThreshold/max(size, 1), 1)
+	if size >= parallelScanThreshold {
+		minLanesPerChunk = len(starts)
+	}
+
+	parallelFor(len(starts), minLanesPerChunk, func(start, end int) {
+		for i := start; i < end; i++ {
+			scanLane(i)
+		}
+	})
+
+	return result
+}
+
+func sequentialScan[T Scalar](values []T, op func(a, b T) T) {
+	for i := 1; i < len(values); i++ {
+		values[i] = op(values[i-1], values[i])
+	}
+}
+
+// Parallel prefix scan: every block is scanned independently, then the total of all the preceding blocks is combined
+// into each block.
+func parallelScan[T Scalar](values []T, op func(a, b T) T) {
+	numBlocks := (len(values) + scanBlockSize - 1) / scanBlockSize
+	block := func(b int) []T {
+		return values[b*scanBlockSize : min((b+1)*scanBlockSize, len(values))]
+	}
+
+	parallelFor(numBlocks, 1, func(start, end int) {
+		for b := start; b < end; b++ {
+			sequentialScan(block(b), op)
+		}
+	})
+
+	// carries[b] is the scan of everything before block b
+	carries := make([]T, numBlocks)
+	for b := 1; b < numBlocks; b++ {
+		previous := block(b - 1)
+		carries[b] = previous[len(previous)-1]
+		if b > 1 {
+			carries[b] = op(carries[b-1], carries[b])
+		}
+	}
+
+	parallelFor(numBlocks-1, 1, func(start, end int) {
+		for b := start + 1; b <= end; b++ {
+			values := block(b)
+			for i := range values {
+				values[i] = op(carries[b], values[i])
+			}
+		}
+	})
+}
@@ -0,0 +1,94 @@
+package tensor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCumSumAndCumProd(t *testing.T) {
+	tensor := WithValue[int]([][]int{{1, 2, 3}, {4, 5, 6}})
+
+	expected := WithValue[int]([][]int{{1, 3, 6}, {4, 9, 15}})
+	result := CumSum(tensor, -1)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[int]([][]int{{1, 2, 3}, {4, 10, 18}})
+	result = CumProd(tensor, 0)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestCumMaxAndCumMin(t *testing.T) {
+	tensor := WithValue[int]([]int{3, 1, 4, 1, 5, 0})
+
+	expected := WithValue[int]([]int{3, 3, 4, 4, 5, 5})
+	result := CumMax(tensor, 0)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[int]([]int{3, 1, 1, 1, 1, 0})
+	result = CumMin(tensor, 0)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestLogCumSumExp(t *testing.T) {
+	tensor := WithValue[float64]([]float64{1000, 1000, math.Inf(-1), 0})
+
+	result := LogCumSumExp(tensor, 0)
+	expected := []float64{1000, 1000 + math.Log(2), 1000 + math.Log(2), 1000 + math.Log(2)}
+	for i, value := range expected {
+		if math.Abs(result.Get(i)-value) > 1e-9 {
+			t.Fatalf("expected %v, got %v", expected, result)
+		}
+	}
+}
+
+func TestCumSumParallel(t *testing.T) {
+	size := 3*parallelScanThreshold + 7
+	tensor := WithShape[int]([]uint{uint(size)}, 1)
+
+	result := CumSum(tensor, 0)
+	for i := 0; i < size; i++ {
+		if result.Get(i) != i+1 {
+			t.Fatalf("expected %d at index %d, got %d", i+1, i, result.Get(i))
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	tensor := WithValue[int]([][]int{{1, 4, 9, 16}, {0, 1, 0, 1}})
+
+	expected := WithValue[int]([][]int{{3, 5, 7}, {1, -1, 1}})
+	result := Diff(tensor, 1, 1)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[int]([][]int{{2, 2}, {-2, 2}})
+	result = Diff(tensor, 2, -1)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	// like numpy.diff(), orders beyond the size of the axis give an empty axis
+	for _, test := range []struct {
+		tensor   *Tensor[int]
+		n        int
+		expected []uint
+	}{
+		{tensor, 4, []uint{2, 0}},
+		{tensor, 6, []uint{2, 0}},
+		{WithShape[int]([]uint{2, 0}), 1, []uint{2, 0}},
+	} {
+		if result := Diff(test.tensor, test.n, 1); !reflect.DeepEqual(test.expected, result.Shape()) {
+			t.Fatalf("Diff(n=%d): expected shape %v, got %v", test.n, test.expected, result.Shape())
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package tensor
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+)
+
+// Sizes in bytes of the element types that tensors can be encoded as. The names are the same as the ones used by the
+// safetensors format.
+var dtypeSizes = map[string]int{
+	"F64":  8,
+	"F32":  4,
+	"F16":  2,
+	"BF16": 2,
+	"I64":  8,
+	"I32":  4,
+	"I16":  2,
+	"I8":   1,
+	"U64":  8,
+	"U32":  4,
+	"U16":  2,
+	"U8":   1,
+}
+
+// Returns the name of the encoded element type for T.
+//
+// Note that int, uint & uintptr are always encoded as 64-bit values, irrespective of the platform.
+func dtypeOf[T Scalar]() string {
+	var zero T
+	switch any(zero).(type) {
+	case float64:
+		return "F64"
+	case float32:
+		return "F32"
+	case Float16:
+		return "F16"
+	case BFloat16:
+		return "BF16"
+	case int, int64:
+		return "I64"
+	case int32:
+		return "I32"
+	case int16:
+		return "I16"
+	case int8:
+		return "I8"
+	case uint, uint64, uintptr:
+		return "U64"
+	case uint32:
+		return "U32"
+	case uint16:
+		return "U16"
+	case uint8:
+		return "U8"
+	default:
+		panic(fmt.Sprintf("Unsupported data type %T", zero))
+	}
+}
+
+// Encodes the elements as little-endian bytes of type dtypeOf[T]().
+func encodeElements[T Scalar](data []T) []byte {
+	dtype := dtypeOf[T]()
+	size := dtypeSizes[dtype]
+	buf := make([]byte, len(data)*size)
+
+	for i, v := range data {
+		b := buf[i*size:]
+		switch dtype {
+		case "F64":
+			binary.LittleEndian.PutUint64(b, math.Float64bits(float64(v)))
+		case "F32":
+			binary.LittleEndian.PutUint32(b, math.Float32bits(float32(v)))
+		case "I64", "U64":
+			binary.LittleEndian.PutUint64(b, uint64(v))
+		case "I32", "U32":
+			binary.LittleEndian.PutUint32(b, uint32(v))
+		case "I16", "U16", "F16", "BF16":
+			binary.LittleEndian.PutUint16(b, uint16(v))
+		case "I8", "U8":
+			b[0] = uint8(v)
+		}
+	}
+
+	return buf
+}
+
+// Decodes little-endian bytes of the given dtype into a slice of T, converting each element to T.
+func decodeElements[T Scalar](buf []byte, dtype string) ([]T, error) {
+	size, ok := dtypeSizes[dtype]
+	if !ok {
+		return nil, fmt.Errorf("%s %q", ErrorUnsupportedDataType, dtype)
+	}
+
+	if len(buf)%size != 0 {
+		return nil, fmt.Errorf("%s %d bytes is not a multiple of the %s element size %d", ErrorInvalidEncoding, len(buf), dtype, size)
+	}
+
+	// the conversions below work on the bits of half-precision types, so decode other types as float64 first
+	if isHalf[T]() && dtype != "F16" && dtype != "BF16" {
+		values, err := decodeElements[float64](buf, dtype)
+		return castSlice[T](values), err
+	}
+
+	data := make([]T, len(buf)/size)
+	for i := range data {
+		b := buf[i*size:]
+		switch dtype {
+		case "F64":
+			data[i] = T(math.Float64frombits(binary.LittleEndian.Uint64(b)))
+		case "F32":
+			data[i] = T(math.Float32frombits(binary.LittleEndian.Uint32(b)))
+		case "F16":
+			data[i] = fromFloat64[T](Float16(binary.LittleEndian.Uint16(b)).Float64())
+		case "BF16":
+			data[i] = fromFloat64[T](BFloat16(binary.LittleEndian.Uint16(b)).Float64())
+		case "I64":
+			data[i] = T(int64(binary.LittleEndian.Uint64(b)))
+		case "I32":
+			data[i] = T(int32(binary.LittleEndian.Uint32(b)))
+		case "I16":
+			data[i] = T(int16(binary.LittleEndian.Uint16(b)))
+		case "I8":
+			data[i] = T(int8(b[0]))
+		case "U64":
+			data[i] = T(binary.LittleEndian.Uint64(b))
+		case "U32":
+			data[i] = T(binary.LittleEndian.Uint32(b))
+		case "U16":
+			data[i] = T(binary.LittleEndian.Uint16(b))
+		case "U8":
+			data[i] = T(b[0])
+		}
+	}
+
+	return data, nil
+}
@@ -0,0 +1,438 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Labels for the dimensions covered by an ellipsis start from here, so that they never clash with letters.
+const ellipsisLabelStart = rune(0x10000)
+
+// An operand of einsum along with the label of each of its dimensions.
+type einsumOperand[T Scalar] struct {
+	tensor *Tensor[T]
+	labels []rune
+}
+
+// Evaluates the Einstein summation convention on the operands, like numpy.einsum().
+//
+// For example, "ij,jk->ik" is matrix multiplication, "ii->" is the trace, "ii->i" is the diagonal, "bij,bjk->bik" is
+// batched matrix multiplication & "i,j->ij" is the outer product. If the output ("->" & what follows) is omitted, it
+// consists of the labels that appear exactly once, in alphabetical order. An ellipsis ("...") stands for any number of
+// dimensions, which are broadcast together.
+//
+// Pairwise contractions are done with MatrixMultiplication(). For three or more operands, the pair whose contraction
+// gives the smallest intermediate result is contracted first.
+func Einsum[T Scalar](subscripts string, operands ...*Tensor[T]) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](Einsum(subscripts, upcastAll(operands)...))
+	}
+
+	if len(operands) == 0 {
+		panic("Einsum(): at least one operand is required!")
+	}
+
+	inputs, outputLabels, sizes := parseEinsumSubscripts(subscripts, operands)
+
+	// how many times is a label used across the inputs & the output
+	counts := map[rune]int{}
+	for _, input := range inputs {
+		for _, label := range uniqueLabels(input.labels) {
+			counts[label]++
+		}
+	}
+
+	for _, label := range outputLabels {
+		counts[label]++
+	}
+
+	for i, input := range inputs {
+		input = broadcastEinsumOperand(input, sizes)
+		input = diagonalEinsumOperand(input)
+
+		// sum out the labels that aren't used anywhere else right away
+		var unused []rune
+		for _, label := range input.labels {
+			if counts[label] == 1 {
+				unused = append(unused, label)
+			}
+		}
+
+		inputs[i] = sumEinsumOperand(input, unused)
+	}
+
+	for len(inputs) > 1 {
+		i, j := chooseEinsumPair(inputs, outputLabels, sizes)
+
+		// the labels that must survive this contraction are the ones used by the output or the other operands
+		keep := map[rune]bool{}
+		for _, label := range outputLabels {
+			keep[label] = true
+		}
+
+		for k, input := range inputs {
+			if k != i && k != j {
+				for _, label := range input.labels {
+					keep[label] = true
+				}
+			}
+		}
+
+		contracted := contractEinsumPair(inputs[i], inputs[j], keep)
+
+		inputs = slices.Delete(inputs, j, j+1)
+		inputs[i] = contracted
+	}
+
+	// sum out whatever isn't in the output & arrange the rest in the order of the output
+	result := inputs[0]
+	var unused []rune
+	for _, label := range result.labels {
+		if !slices.Contains(outputLabels, label) {
+			unused = append(unused, label)
+		}
+	}
+
+	result = sumEinsumOperand(result, unused)
+
+	axes := make([]int, len(outputLabels))
+	for i, label := range outputLabels {
+		axes[i] = slices.Index(result.labels, label)
+	}
+
+	return permute(result.tensor, axes)
+}
+
+// Parses the subscripts into labelled operands & the output labels, and determines the size of every label.
+func parseEinsumSubscripts[T Scalar](subscripts string, operands []*Tensor[T]) ([]einsumOperand[T], []rune, map[rune]uint) {
+	subscripts = strings.ReplaceAll(subscripts, " ", "")
+
+	inputPart, outputPart, hasOutput := strings.Cut(subscripts, "->")
+	terms := strings.Split(inputPart, ",")
+	if len(terms) != len(operands) {
+		panic(fmt.Sprintf("Einsum(): %d subscripts were given for %d operands", len(terms), len(operands)))
+	}
+
+	// parse the letters before & after the ellipsis of every term
+	type term struct {
+		before, after []rune
+		hasEllipsis   bool
+	}
+
+	parsedTerms := make([]term, len(terms))
+	numEllipsisDims := 0
+	for i, s := range terms {
+		before, after, hasEllipsis := strings.Cut(s, "...")
+		parsedTerms[i] = term{
+			before:      parseEinsumLetters(before, s),
+			after:       parseEinsumLetters(after, s),
+			hasEllipsis: hasEllipsis,
+		}
+
+		numLetters := len(parsedTerms[i].before) + len(parsedTerms[i].after)
+		numDims := operands[i].NDims()
+		if numDims < numLetters || (!hasEllipsis && numDims != numLetters) {
+			panic(fmt.Sprintf("Einsum(): subscripts %q don't match operand %d of shape %v", s, i, operands[i].shape))
+		}
+
+		numEllipsisDims = max(numEllipsisDims, numDims-numLetters)
+	}
+
+	ellipsisLabels := make([]rune, numEllipsisDims)
+	for i := range ellipsisLabels {
+		ellipsisLabels[i] = ellipsisLabelStart + rune(i)
+	}
+
+	inputs := make([]einsumOperand[T], len(operands))
+	sizes := map[rune]uint{}
+	for i, t := range parsedTerms {
+		labels := append([]rune{}, t.before...)
+
+		// the dimensions of the ellipsis are aligned to the right, like in broadcasting
+		n := operands[i].NDims() - len(t.before) - len(t.after)
+		labels = append(labels, ellipsisLabels[numEllipsisDims-n:]...)
+		labels = append(labels, t.after...)
+
+		for dim, label := range labels {
+			size := operands[i].shape[dim]
+			existing, ok := sizes[label]
+			switch {
+			case !ok || existing == size:
+				sizes[label] = size
+			case label >= ellipsisLabelStart && existing == 1:
+				// like in broadcasting, a size of 1 takes the other size, even 0
+				sizes[label] = size
+			case label >= ellipsisLabelStart && size == 1:
+			default:
+				panic(fmt.Sprintf("Einsum(): size %d of label %q in operand %d conflicts with size %d", size, label, i, existing))
+			}
+		}
+
+		inputs[i] = einsumOperand[T]{tensor: operands[i], labels: labels}
+	}
+
+	if !hasOutput {
+		// implicit output: the ellipsis followed by the labels that appear exactly once, in alphabetical order
+		counts := map[rune]int{}
+		for _, input := range inputs {
+			for _, label := range input.labels {
+				counts[label]++
+			}
+		}
+
+		var once []rune
+		for label, count := range counts {
+			if count == 1 && label < ellipsisLabelStart {
+				once = append(once, label)
+			}
+		}
+
+		slices.Sort(once)
+		return inputs, append(ellipsisLabels, once...), sizes
+	}
+
+	before, after, hasEllipsis := strings.Cut(outputPart, "...")
+	outputLabels := parseEinsumLetters(before, outputPart)
+	if hasEllipsis {
+		outputLabels = append(outputLabels, ellipsisLabels...)
+	}
+
+	outputLabels = append(outputLabels, parseEinsumLetters(after, outputPart)...)
+
+	for i, label := range outputLabels {
+		if _, ok := sizes[label]; !ok {
+			panic(fmt.Sprintf("Einsum(): output label %q doesn't appear in the inputs", label))
+		}
+
+		if slices.Contains(outputLabels[:i], label) {
+			panic(fmt.Sprintf("Einsum(): output label %q appears more than once", label))
+		}
+	}
+
+	return inputs, outputLabels, sizes
+}
+
+// Parses a run of letters from the subscripts. term is used for error messages.
+func parseEinsumLetters(s, term string) []rune {
+	letters := []rune(s)
+	for _, letter := range letters {
+		if (letter < 'a' || letter > 'z') && (letter < 'A' || letter > 'Z') {
+			panic(fmt.Sprintf("Einsum(): invalid subscript %q in %q", letter, term))
+		}
+	}
+
+	return letters
+}
+
+// Returns the labels without repetitions, in order of their first appearance.
+func uniqueLabels(labels []rune) []rune {
+	unique := []rune{}
+	for _, label := range labels {
+		if !slices.Contains(unique, label) {
+			unique = append(unique, label)
+		}
+	}
+
+	return unique
+}
+
+// Expands the dimensions of size 1 whose labels have a bigger size in another operand.
+func broadcastEinsumOperand[T Scalar](operand einsumOperand[T], sizes map[rune]uint) einsumOperand[T] {
+	t := operand.tensor
+
+	shape := make([]uint, len(operand.labels))
+	needsBroadcast := false
+	for i, label := range operand.labels {
+		shape[i] = sizes[label]
+		needsBroadcast = needsBroadcast || shape[i] != t.shape[i]
+	}
+
+	if !needsBroadcast {
+		return operand
+	}
+
+	b := &BroadcastTensor[T]{shape: shape, tensor: t}
+	return einsumOperand[T]{tensor: b.ToTensor(), labels: operand.labels}
+}
+
+// Takes the diagonal along the dimensions with repeated labels, for example "ii" becomes "i".
+func diagonalEinsumOperand[T Scalar](operand einsumOperand[T]) einsumOperand[T] {
+	labels := uniqueLabels(operand.labels)
+	if len(labels) == len(operand.labels) {
+		return operand
+	}
+
+	t := operand.tensor
+	shape := make([]uint, len(labels))
+	for i, label := range labels {
+		shape[i] = t.shape[slices.Index(operand.labels, label)]
+	}
+
+	// the stride of a label in the source is the sum of the strides of all the dimensions with that label
+	strides := make([]uint, len(labels))
+	for dim, label := range operand.labels {
+		strides[slices.Index(labels, label)] += t.strides[dim]
+	}
+
+	return einsumOperand[T]{tensor: gatherStrided(t.data, shape, strides), labels: labels}
+}
+
+// Sums the operand along the dimensions with the given labels.
+func sumEinsumOperand[T Scalar](operand einsumOperand[T], labels []rune) einsumOperand[T] {
+	t := operand.tensor
+	remaining := slices.Clone(operand.labels)
+
+	for _, label := range labels {
+		axis := slices.Index(remaining, label)
+		t = reduceAxis(t, axis, 0, func(accumulator, value T) T { return accumulator + value })
+		remaining = slices.Delete(remaining, axis, axis+1)
+	}
+
+	return einsumOperand[T]{tensor: t, labels: remaining}
+}
+
+// Chooses the pair of operands to contract next, i.e. the one with the smallest result.
+func chooseEinsumPair[T Scalar](inputs []einsumOperand[T], outputLabels []rune, sizes map[rune]uint) (int, int) {
+	if len(inputs) == 2 {
+		return 0, 1
+	}
+
+	bestI, bestJ := 0, 1
+	bestSize := ^uint(0)
+	for i := 0; i < len(inputs); i++ {
+		for j := i + 1; j < len(inputs); j++ {
+			// labels of the pair that are needed by the output or any other operand survive the contraction
+			size := uint(1)
+			for _, label := range uniqueLabels(append(slices.Clone(inputs[i].labels), inputs[j].labels...)) {
+				needed := slices.Contains(outputLabels, label)
+				for k := 0; k < len(inputs) && !needed; k++ {
+					needed = k != i && k != j && slices.Contains(inputs[k].labels, label)
+				}
+
+				if needed {
+					size *= sizes[label]
+				}
+			}
+
+			if size < bestSize {
+				bestI, bestJ, bestSize = i, j, size
+			}
+		}
+	}
+
+	return bestI, bestJ
+}
+
+// Contracts two operands over their shared labels that are not in keep, using batched matrix multiplication.
+func contractEinsumPair[T Scalar](a, b einsumOperand[T], keep map[rune]bool) einsumOperand[T] {
+	// labels present in only one of the operands & not needed anymore can be summed right away
+	var unusedA, unusedB []rune
+	for _, label := range a.labels {
+		if !keep[label] && !slices.Contains(b.labels, label) {
+			unusedA = append(unusedA, label)
+		}
+	}
+
+	for _, label := range b.labels {
+		if !keep[label] && !slices.Contains(a.labels, label) {
+			unusedB = append(unusedB, label)
+		}
+	}
+
+	a = sumEinsumOperand(a, unusedA)
+	b = sumEinsumOperand(b, unusedB)
+
+	var batch, left, contracted, right []rune
+	for _, label := range a.labels {
+		switch {
+		case !slices.Contains(b.labels, label):
+			left = append(left, label)
+		case keep[label]:
+			batch = append(batch, label)
+		default:
+			contracted = append(contracted, label)
+		}
+	}
+
+	for _, label := range b.labels {
+		if !slices.Contains(a.labels, label) {
+			right = append(right, label)
+		}
+	}
+
+	// arrange a as (batch, left, contracted) & b as (batch, contracted, right)
+	aLabels := slices.Concat(batch, left, contracted)
+	bLabels := slices.Concat(batch, contracted, right)
+	aPermuted := permute(a.tensor, labelAxes(a.labels, aLabels))
+	bPermuted := permute(b.tensor, labelAxes(b.labels, bLabels))
+
+	numBatches := countElementsFromShape(aPermuted.shape[:len(batch)])
+	numRows := countElementsFromShape(aPermuted.shape[len(batch) : len(batch)+len(left)])
+	numInner := countElementsFromShape(aPermuted.shape[len(batch)+len(left):])
+	numCols := countElementsFromShape(bPermuted.shape[len(batch)+len(contracted):])
+
+	resultShape := slices.Concat(
+		aPermuted.shape[:len(batch)+len(left)],
+		bPermuted.shape[len(batch)+len(contracted):],
+	)
+
+	resultData := make([]T, 0, numBatches*numRows*numCols)
+	for i := uint(0); i < numBatches; i++ {
+		aMatrix := fromData([]uint{numRows, numInner}, aPermuted.data[i*numRows*numInner:(i+1)*numRows*numInner])
+		bMatrix := fromData([]uint{numInner, numCols}, bPermuted.data[i*numInner*numCols:(i+1)*numInner*numCols])
+		resultData = append(resultData, MatrixMultiplication(aMatrix, bMatrix).data...)
+	}
+
+	return einsumOperand[T]{
+		tensor: fromData(resultShape, resultData),
+		labels: slices.Concat(batch, left, right),
+	}
+}
+
+// Returns the positions in labels of each of the wanted labels.
+func labelAxes(labels, wanted []rune) []int {
+	axes := make([]int, len(wanted))
+	for i, label := range wanted {
+		axes[i] = slices.Index(labels, label)
+	}
+
+	return axes
+}
+
+// Returns a new tensor with the dimensions of t rearranged so that dimension i of the result is dimension axes[i] of t.
+func permute[T Scalar](t *Tensor[T], axes []int) *Tensor[T] {
+	shape := make([]uint, len(axes))
+	strides := make([]uint, len(axes))
+	for i, axis := range axes {
+		shape[i] = t.shape[axis]
+		strides[i] = t.strides[axis]
+	}
+
+	return gatherStrided(t.data, shape, strides)
+}
+
+// Creates a new tensor of the given shape whose element at indices is data[sum(indices[i] * strides[i])].
+func gatherStrided[T Scalar](data []T, shape, strides []uint) *Tensor[T] {
+	result := WithShape[T](shape)
+
+	indices := make([]int, len(shape))
+	dataIndex := 0
+	for i := range result.data {
+		result.data[i] = data[dataIndex]
+
+		// move on to the next indices in row-major order, keeping track of the data index
+		for dim := len(shape) - 1; dim >= 0; dim-- {
+			indices[dim]++
+			dataIndex += int(strides[dim])
+			if indices[dim] < int(shape[dim]) {
+				break
+			}
+
+			dataIndex -= indices[dim] * int(strides[dim])
+			indices[dim] = 0
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,104 @@
+package tensor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestEinsumMatrixMultiplication(t *testing.T) {
+	a := WithValue[int]([][]int{{1, 2, 3}, {4, 5, 6}})
+	b := WithValue[int]([][]int{{1, 0}, {0, 1}, {2, -1}})
+
+	expected := MatrixMultiplication(a, b)
+	for _, subscripts := range []string{"ij,jk->ik", "ij,jk"} {
+		result := Einsum(subscripts, a, b)
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("%s: expected %v, got %v", subscripts, expected, result)
+		}
+	}
+
+	// implicit output is in alphabetical order, so this is the transpose of the product
+	result := Einsum("kj,ji", b.Transpose(), a.Transpose())
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestEinsumTraceAndDiagonal(t *testing.T) {
+	a := WithValue[int]([][]int{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}})
+
+	expected := WithValue[int](15)
+	result := Einsum("ii", a)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[int]([]int{1, 5, 9})
+	result = Einsum("ii->i", a)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[int]([]int{12, 15, 18})
+	result = Einsum("ij->j", a)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestEinsumEllipsis(t *testing.T) {
+	// batched matrix multiplication where the second operand is broadcast over the batch
+	a := WithValue[int]([][][]int{
+		{{1, 2}, {3, 4}},
+		{{5, 6}, {7, 8}},
+	})
+	b := WithValue[int]([][][]int{{{1, 1}, {0, 2}}})
+
+	expected := WithValue[int]([][][]int{
+		{{1, 5}, {3, 11}},
+		{{5, 17}, {7, 23}},
+	})
+	result := Einsum("...ij,...jk->...ik", a, b)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	// a size of 1 broadcasts to a size of 0, whichever operand comes first
+	empty, single := WithShape[float64]([]uint{0, 3}), WithShape[float64]([]uint{1, 3})
+	for _, operands := range [][2]*Tensor[float64]{{empty, single}, {single, empty}} {
+		if result := Einsum("...i,...i->...", operands[0], operands[1]); !reflect.DeepEqual([]uint{0}, result.Shape()) {
+			t.Fatalf("expected shape [0], got %v", result.Shape())
+		}
+	}
+}
+
+func TestEinsumMultipleOperands(t *testing.T) {
+	x := WithValue[float64]([][]float64{{1, 2}, {3, 4}})
+	w := WithValue[float64]([][][]float64{
+		{{1, 0}, {0, 1}},
+		{{2, 1}, {1, 2}},
+		{{0, 1}, {1, 0}},
+	})
+	y := WithValue[float64]([][]float64{{1, -1}, {2, 0}})
+
+	// bilinear layer: out[b, o] = sum_ij x[b, i] * w[o, i, j] * y[b, j]
+	result := Einsum("bi,oij,bj->bo", x, w, y)
+
+	expected := WithShape[float64]([]uint{2, 3})
+	for b := 0; b < 2; b++ {
+		for o := 0; o < 3; o++ {
+			sum := 0.0
+			for i := 0; i < 2; i++ {
+				for j := 0; j < 2; j++ {
+					sum += x.Get(b, i) * w.Get(o, i, j) * y.Get(b, j)
+				}
+			}
+
+			expected.Set([]int{b, o}, sum)
+		}
+	}
+
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
@@ -21,4 +21,25 @@ const (
 
 	// Error message for tensors incompatible for broadcast
 	ErrorCannotBroadcast = "Tensors could not be broadcast together!"
+
+	// Error message for an index outside of the dimension it indexes.
+	ErrorIndexOutOfBounds = "Index out of bounds!"
+
+	// Error message for an element type that cannot be encoded or decoded.
+	ErrorUnsupportedDataType = "Unsupported data type!"
+
+	// Error message for malformed encoded tensor data.
+	ErrorInvalidEncoding = "Invalid encoded tensor data!"
+
+	// Error message for a malformed safetensors file.
+	ErrorInvalidSafetensors = "Invalid safetensors data!"
+
+	// Error message for a malformed .npy file.
+	ErrorInvalidNpy = "Invalid .npy data!"
+
+	// Error message for memory-mapping on a platform where it's not supported.
+	ErrorMmapUnsupported = "Memory-mapped tensors are not supported on this platform!"
+
+	// Error message for using a memory-mapped tensor after it was closed.
+	ErrorMmapClosed = "Memory-mapped tensor is already closed!"
 )
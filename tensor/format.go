@@ -0,0 +1,339 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Options that control how tensors are printed. They are similar to numpy.set_printoptions().
+type PrintOptions struct {
+	// Number of digits after the decimal point for floats. Negative means the shortest representation that parses
+	// back to the same value.
+	Precision int
+
+	// Number of characters per line after which the elements of a row are wrapped onto the next line.
+	LineWidth int
+
+	// Total number of elements above which the tensor is summarized, i.e. only the first & last EdgeItems of each
+	// dimension are printed with "..." in between.
+	Threshold uint
+
+	// Number of elements printed at the start & end of each dimension when summarizing.
+	EdgeItems uint
+
+	// If true, floats are always printed in fixed-point notation, so values too small for the precision print as zero.
+	// Otherwise, scientific notation is used for the whole tensor when such values are present. It has no effect if
+	// Precision is negative.
+	SuppressSmall bool
+}
+
+// The print options used by default.
+var DefaultPrintOptions = PrintOptions{
+	Precision: -1,
+	LineWidth: 75,
+	Threshold: 1000,
+	EdgeItems: 3,
+}
+
+var (
+	printOptionsMutex sync.RWMutex
+	printOptions      = DefaultPrintOptions
+)
+
+// Sets the print options used by String() & fmt, and returns the previous ones so that they can be restored.
+func SetPrintOptions(opts PrintOptions) (previous PrintOptions) {
+	printOptionsMutex.Lock()
+	defer printOptionsMutex.Unlock()
+
+	previous = printOptions
+	printOptions = opts
+	return previous
+}
+
+// Returns the current print options.
+func GetPrintOptions() PrintOptions {
+	printOptionsMutex.RLock()
+	defer printOptionsMutex.RUnlock()
+
+	return printOptions
+}
+
+// Returns a string representation of the tensor.
+func (t *Tensor[T]) String() string {
+	return t.format(GetPrintOptions(), 'v', -1, 0, false)
+}
+
+// Implements fmt.Formatter.
+//
+// %v & %s use the current print options. A precision, as in %.3v, overrides the one in the print options & the plus
+// flag (%+v) also prints the shape & data type. %f, %e & %g (and their uppercase variants) format floats like strconv
+// does, and %d, %x, %o & %b format integers. A width, as in %8v, is the minimum width of each element.
+func (t *Tensor[T]) Format(s fmt.State, verb rune) {
+	opts := GetPrintOptions()
+
+	precision, hasPrecision := s.Precision()
+	if !hasPrecision {
+		precision = -1
+	}
+
+	width, _ := s.Width()
+
+	switch verb {
+	case 'v', 's':
+		if hasPrecision {
+			opts.Precision = precision
+		}
+		verb = 'v'
+	case 'f', 'F', 'e', 'E', 'g', 'G', 'd', 'x', 'X', 'o', 'b':
+		// handled while formatting the elements
+	default:
+		fmt.Fprintf(s, "%%!%c(%s)", verb, t.String())
+		return
+	}
+
+	fmt.Fprint(s, t.format(opts, verb, precision, width, s.Flag('+')))
+}
+
+// Formats the tensor. The verb & precision are the ones passed to Format(), with -1 meaning no precision.
+func (t *Tensor[T]) format(opts PrintOptions, verb rune, precision, width int, verbose bool) string {
+	p := &tensorPrinter[T]{
+		tensor:    t,
+		opts:      opts,
+		summarize: countElementsFromShape(t.shape) > opts.Threshold,
+	}
+
+	// first collect the elements that will actually be printed, since their formatting (scientific notation or not,
+	// padding) depends on all of them
+	values := []T{}
+	p.walk(0, 0, func(dataIndex int) {
+		values = append(values, t.data[dataIndex])
+	})
+
+	p.elements = formatElements(values, opts, verb, precision)
+	p.width = width
+	for _, s := range p.elements {
+		p.width = max(p.width, len(s))
+	}
+
+	var sb strings.Builder
+	sb.WriteString("Tensor(")
+	if t.NDims() == 0 {
+		sb.WriteString(p.elements[0])
+	} else {
+		p.write(&sb, 0, 0, 0)
+	}
+
+	if verbose {
+		fmt.Fprintf(&sb, ", shape=%v, dtype=%v", t.shape, t.dataType)
+	}
+
+	sb.WriteString(")")
+	return sb.String()
+}
+
+type tensorPrinter[T Scalar] struct {
+	tensor    *Tensor[T]
+	opts      PrintOptions
+	summarize bool
+
+	// formatted elements in the order they are printed
+	elements []string
+
+	// index of the next element to be printed
+	next int
+
+	// width to which every element is padded
+	width int
+}
+
+// Returns the indices of the given dimension that will be printed. -1 stands for "...".
+func (p *tensorPrinter[T]) indices(dim int) []int {
+	size := int(p.tensor.shape[dim])
+	edgeItems := int(p.opts.EdgeItems)
+
+	if !p.summarize || size <= 2*edgeItems {
+		indices := make([]int, size)
+		for i := range indices {
+			indices[i] = i
+		}
+
+		return indices
+	}
+
+	indices := make([]int, 0, 2*edgeItems+1)
+	for i := 0; i < edgeItems; i++ {
+		indices = append(indices, i)
+	}
+
+	indices = append(indices, -1)
+	for i := size - edgeItems; i < size; i++ {
+		indices = append(indices, i)
+	}
+
+	return indices
+}
+
+// Calls visit with the data index of every printed element, in order.
+func (p *tensorPrinter[T]) walk(dim, dataIndex int, visit func(dataIndex int)) {
+	if dim == p.tensor.NDims() {
+		visit(dataIndex)
+		return
+	}
+
+	for _, i := range p.indices(dim) {
+		if i >= 0 {
+			p.walk(dim+1, dataIndex+i*int(p.tensor.strides[dim]), visit)
+		}
+	}
+}
+
+// Writes the given dimension, whose opening bracket is preceded by indentation spaces on its line.
+func (p *tensorPrinter[T]) write(sb *strings.Builder, dim, dataIndex, indentation int) {
+	sb.WriteString("[")
+
+	if dim == p.tensor.NDims()-1 {
+		lineLength := indentation + 1
+		for j, i := range p.indices(dim) {
+			s := "..."
+			if i >= 0 {
+				s = p.elements[p.next]
+				s = strings.Repeat(" ", max(p.width-len(s), 0)) + s
+				p.next++
+			}
+
+			if j > 0 {
+				// wrap onto the next line, aligned with the first element, if the line would get too long
+				if p.opts.LineWidth > 0 && lineLength+1+len(s)+1 > p.opts.LineWidth {
+					sb.WriteString("\n" + strings.Repeat(" ", indentation+1))
+					lineLength = indentation + 1
+				} else {
+					sb.WriteString(" ")
+					lineLength++
+				}
+			}
+
+			sb.WriteString(s)
+			lineLength += len(s)
+		}
+
+		sb.WriteString("]")
+		return
+	}
+
+	// like NumPy, an empty dimension hides the ones after it
+	if p.tensor.shape[dim] == 0 {
+		sb.WriteString("]")
+		return
+	}
+
+	childIndentation := strings.Repeat(" ", indentation+2)
+	for _, i := range p.indices(dim) {
+		sb.WriteString("\n" + childIndentation)
+		if i < 0 {
+			sb.WriteString("...")
+			continue
+		}
+
+		p.write(sb, dim+1, dataIndex+i*int(p.tensor.strides[dim]), indentation+2)
+	}
+
+	sb.WriteString("\n" + strings.Repeat(" ", indentation) + "]")
+}
+
+// Formats the elements according to the print options, or the verb & precision if it's not 'v'.
+func formatElements[T Scalar](values []T, opts PrintOptions, verb rune, precision int) []string {
+	elements := make([]string, len(values))
+
+	var zero T
+	dataType := reflect.TypeOf(zero)
+	kind := dataType.Kind()
+	isFloat := kind == reflect.Float32 || kind == reflect.Float64 || isHalf[T]()
+
+	if !isFloat {
+		base := 10
+		switch verb {
+		case 'x', 'X':
+			base = 16
+		case 'o':
+			base = 8
+		case 'b':
+			base = 2
+		}
+
+		for i, v := range values {
+			rv := reflect.ValueOf(v)
+			if rv.CanInt() {
+				elements[i] = strconv.FormatInt(rv.Int(), base)
+			} else {
+				elements[i] = strconv.FormatUint(rv.Uint(), base)
+			}
+
+			if verb == 'X' {
+				elements[i] = strings.ToUpper(elements[i])
+			}
+		}
+
+		return elements
+	}
+
+	format := byte('g')
+	switch verb {
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		format = byte(verb)
+		if format == 'F' {
+			format = 'f'
+		}
+
+		if precision < 0 && format != 'g' && format != 'G' {
+			precision = 6
+		}
+	default:
+		precision = opts.Precision
+		if precision >= 0 {
+			format = floatFormatFor(values, precision, opts.SuppressSmall)
+		}
+	}
+
+	bitSize := dataType.Bits()
+	if isHalf[T]() {
+		bitSize = 32
+	}
+
+	for i, v := range values {
+		if isHalf[T]() && precision < 0 && format == 'g' {
+			// strconv can't find the shortest representation of 16-bit floats
+			elements[i] = formatScalar(v)
+			continue
+		}
+
+		elements[i] = strconv.FormatFloat(toFloat64(v), format, precision, bitSize)
+	}
+
+	return elements
+}
+
+// Decides whether floats printed with the given precision should use fixed-point ('f') or scientific ('e') notation.
+// Like NumPy, it's decided for all the elements together.
+func floatFormatFor[T Scalar](values []T, precision int, suppressSmall bool) byte {
+	if suppressSmall {
+		return 'f'
+	}
+
+	smallest := math.Pow(10, -float64(precision))
+	for _, v := range values {
+		abs := math.Abs(toFloat64(v))
+		if math.IsNaN(abs) || math.IsInf(abs, 0) || abs == 0 {
+			continue
+		}
+
+		if abs < smallest || abs >= 1e16 {
+			return 'e'
+		}
+	}
+
+	return 'f'
+}
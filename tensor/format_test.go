@@ -0,0 +1,65 @@
+package tensor
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestString(t *testing.T) {
+	tensor := WithValue[int]([][]int{{1, -20}, {300, 4}})
+
+	expected := "Tensor([\n  [  1 -20]\n  [300   4]\n])"
+	if tensor.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, tensor.String())
+	}
+
+	scalar := WithValue[float64](2.5)
+	if scalar.String() != "Tensor(2.5)" {
+		t.Fatalf("expected %q, got %q", "Tensor(2.5)", scalar.String())
+	}
+}
+
+func TestStringSummarized(t *testing.T) {
+	previous := SetPrintOptions(PrintOptions{Precision: 1, LineWidth: 75, Threshold: 5, EdgeItems: 2})
+	defer SetPrintOptions(previous)
+
+	tensor := WithShape[float64]([]uint{10}, 0.25)
+
+	expected := "Tensor([0.2 0.2 ... 0.2 0.2])"
+	if tensor.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, tensor.String())
+	}
+}
+
+func TestStringLineWidth(t *testing.T) {
+	previous := SetPrintOptions(PrintOptions{Precision: -1, LineWidth: 12, Threshold: 1000})
+	defer SetPrintOptions(previous)
+
+	tensor := WithValue[int]([]int{1, 2, 3, 4, 5, 6, 7})
+
+	expected := "Tensor([1 2 3 4 5\n 6 7])"
+	if tensor.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, tensor.String())
+	}
+}
+
+func TestFormat(t *testing.T) {
+	tensor := WithValue[float64]([]float64{1, 0.12345, 1e-6})
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{"%v", "Tensor([      1 0.12345   1e-06])"},
+		{"%.3f", "Tensor([1.000 0.123 0.000])"},
+		{"%.2v", "Tensor([1.00e+00 1.23e-01 1.00e-06])"},
+		{"%+v", "Tensor([      1 0.12345   1e-06], shape=[3], dtype=float64)"},
+	}
+
+	for _, test := range tests {
+		result := fmt.Sprintf(test.format, tensor)
+		if result != test.expected {
+			t.Fatalf("%s: expected %q, got %q", test.format, test.expected, result)
+		}
+	}
+}
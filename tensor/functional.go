@@ -0,0 +1,134 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Returns a new tensor with f applied to every element of the given tensor.
+//
+// Like the other functions taking callbacks, it doesn't accept half-precision tensors, since the Go operators in f
+// would work on their bits. Cast them to float32 instead, e.g. Cast[Float16](Map(Cast[float32](t), f)).
+func Map[T NumericScalarReal](t *Tensor[T], f func(value T) T) *Tensor[T] {
+	result := t.Copy()
+	for i, value := range result.data {
+		result.data[i] = f(value)
+	}
+
+	return result
+}
+
+// Returns a new tensor with f applied to the elements of the two tensors, after broadcasting them together like Add()
+// does. Like for Map(), half-precision tensors must be cast to float32 first.
+func Map2[T NumericScalarReal](t1, t2 *Tensor[T], f func(a, b T) T) *Tensor[T] {
+	broadcasts := Broadcast(t1, t2)
+	b1 := broadcasts[0]
+	b2 := broadcasts[1]
+
+	result := WithShape[T](b1.shape)
+	numElements := int(countElementsFromShape(result.shape))
+	for i := 0; i < numElements; i++ {
+		result.data[i] = f(b1.FlattenedGet(i), b2.FlattenedGet(i))
+	}
+
+	return result
+}
+
+// Returns a new tensor with f applied to the elements of all the tensors, after broadcasting them together. The values
+// passed to f are in the same order as the tensors, and the slice is reused between calls. Like for Map(),
+// half-precision tensors must be cast to float32 first.
+func MapN[T NumericScalarReal](tensors []*Tensor[T], f func(values []T) T) *Tensor[T] {
+	if len(tensors) == 0 {
+		panic("At least one tensor is required!")
+	}
+
+	broadcasts := Broadcast(tensors...)
+
+	result := WithShape[T](broadcasts[0].shape)
+	numElements := int(countElementsFromShape(result.shape))
+	values := make([]T, len(broadcasts))
+	for i := 0; i < numElements; i++ {
+		for j, b := range broadcasts {
+			values[j] = b.FlattenedGet(i)
+		}
+
+		result.data[i] = f(values)
+	}
+
+	return result
+}
+
+// Reduces the tensor along the axis using f, starting with the initial value for every lane. The axis is removed from
+// the shape of the result. Negative axes count from the end. Like for Map(), half-precision tensors must be cast to
+// float32 first.
+//
+// For example, ReduceAxis(t, 0, 0, func(acc, value int) int { return acc + value }) sums the rows of a matrix.
+func ReduceAxis[T NumericScalarReal](t *Tensor[T], axis int, initial T, f func(accumulator, value T) T) *Tensor[T] {
+	return reduceAxis(t, axis, initial, f)
+}
+
+// Like ReduceAxis(), for any Scalar, so f must handle the bits of half-precision values itself.
+func reduceAxis[T Scalar](t *Tensor[T], axis int, initial T, f func(accumulator, value T) T) *Tensor[T] {
+	axis = normalizeAxis(axis, t.NDims())
+
+	result := WithShape[T](removeAxis(t.shape, axis))
+	size := int(t.shape[axis])
+	stride := int(t.strides[axis])
+	for lane, start := range laneStarts(t.shape, t.strides, axis) {
+		accumulator := initial
+		for i := 0; i < size; i++ {
+			accumulator = f(accumulator, t.data[start+i*stride])
+		}
+
+		result.data[lane] = accumulator
+	}
+
+	return result
+}
+
+// Calls f with every 1D lane of the tensor along the axis & returns a tensor made of the results. f must return slices
+// of the same length for every lane, which becomes the size of the axis in the result. If the tensor has no lanes, f is
+// never called & the result is an empty tensor of the same shape. The lane slice passed to f is reused between calls.
+// Negative axes count from the end. Like for Map(), half-precision tensors must be cast to float32 first.
+//
+// For example, ApplyAlongAxis(t, -1, normalize) normalizes every row of a matrix.
+func ApplyAlongAxis[T NumericScalarReal](t *Tensor[T], axis int, f func(lane []T) []T) *Tensor[T] {
+	axis = normalizeAxis(axis, t.NDims())
+
+	size := int(t.shape[axis])
+	stride := int(t.strides[axis])
+	lane := make([]T, size)
+
+	var result *Tensor[T]
+	var resultStarts []int
+	for i, start := range laneStarts(t.shape, t.strides, axis) {
+		for j := range lane {
+			lane[j] = t.data[start+j*stride]
+		}
+
+		output := f(lane)
+
+		// the shape of the result is known only after the first call
+		if result == nil {
+			resultShape := make([]uint, len(t.shape))
+			copy(resultShape, t.shape)
+			resultShape[axis] = uint(len(output))
+
+			result = WithShape[T](resultShape)
+			resultStarts = laneStarts(result.shape, result.strides, axis)
+		} else if uint(len(output)) != result.shape[axis] {
+			panic(fmt.Sprintf("ApplyAlongAxis(): f returned %d values for lane %d, expected %d", len(output), i, result.shape[axis]))
+		}
+
+		resultStride := int(result.strides[axis])
+		for j, value := range output {
+			result.data[resultStarts[i]+j*resultStride] = value
+		}
+	}
+
+	if result == nil {
+		return WithShape[T](slices.Clone(t.shape))
+	}
+
+	return result
+}
@@ -0,0 +1,86 @@
+package tensor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMap(t *testing.T) {
+	tensor := WithValue[int]([][]int{{1, -2}, {3, -4}})
+
+	expected := WithValue[int]([][]int{{1, 0}, {3, 0}})
+	result := Map(tensor, func(value int) int { return max(value, 0) })
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestMap2(t *testing.T) {
+	expected := WithValue[int]([][]int{
+		{1, 2, 3},
+		{2, 2, 3},
+		{3, 3, 3},
+	})
+
+	result := Map2(t1, t2, func(a, b int) int { return max(a, b) })
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestMapN(t *testing.T) {
+	condition := WithValue[int]([]int{1, 0, 1})
+	a := WithValue[int]([]int{10, 20, 30})
+	b := WithValue[int]([][]int{{-1}, {-2}})
+
+	expected := WithValue[int]([][]int{{10, -1, 30}, {10, -2, 30}})
+	result := MapN([]*Tensor[int]{condition, a, b}, func(values []int) int {
+		if values[0] != 0 {
+			return values[1]
+		}
+
+		return values[2]
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	defer func() {
+		if message, _ := recover().(string); message != ErrorCannotBroadcast {
+			t.Fatalf("expected a %q panic for [1], [2] & [3], got %q", ErrorCannotBroadcast, message)
+		}
+	}()
+
+	incompatible := []*Tensor[int]{WithValue[int]([]int{1}), WithValue[int]([]int{1, 2}), WithValue[int]([]int{1, 2, 3})}
+	MapN(incompatible, func(values []int) int { return values[0]*100 + values[1]*10 + values[2] })
+}
+
+func TestReduceAxis(t *testing.T) {
+	tensor := WithValue[int]([][]int{{1, 2, 3}, {4, 5, 6}})
+	sum := func(accumulator, value int) int { return accumulator + value }
+
+	expected := WithValue[int]([]int{5, 7, 9})
+	result := ReduceAxis(tensor, 0, 0, sum)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[int]([]int{6, 15})
+	result = ReduceAxis(tensor, -1, 0, sum)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestApplyAlongAxis(t *testing.T) {
+	tensor := WithValue[int]([][]int{{1, 2, 3}, {4, 5, 6}})
+
+	// first & last element of every column
+	expected := WithValue[int]([][]int{{1, 2, 3}, {4, 5, 6}, {5, 7, 9}})
+	result := ApplyAlongAxis(tensor, 0, func(lane []int) []int {
+		return []int{lane[0], lane[1], lane[0] + lane[1]}
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
@@ -0,0 +1,294 @@
+package tensor
+
+import (
+	"encoding/json"
+	"math"
+	"slices"
+	"strconv"
+)
+
+// Float16 is an IEEE 754 half-precision float with 1 sign bit, 5 exponent bits & 10 mantissa bits. It's meant for
+// storage: arithmetic on tensors of Float16 is performed in float32, and the results are rounded back.
+//
+// Note that the Go operators work on the underlying bits, so use Float32() to do arithmetic on individual values.
+type Float16 uint16
+
+// BFloat16 is a "brain" float with 1 sign bit, 8 exponent bits & 7 mantissa bits, i.e. the upper half of a float32. It
+// has the range of a float32 with less precision. Like Float16, it's meant for storage.
+type BFloat16 uint16
+
+// HalfScalar is a 16-bit floating-point type.
+type HalfScalar interface {
+	Float16 | BFloat16
+}
+
+const (
+	float16ExponentBits = 5
+	float16MantissaBits = 10
+
+	bfloat16ExponentBits = 8
+	bfloat16MantissaBits = 7
+)
+
+// Converts a float32 to the nearest Float16, with ties rounded to even. Values too large for a Float16 become infinite.
+func Float16FromFloat32(f float32) Float16 {
+	return Float16(roundToHalf(float64(f), float16ExponentBits, float16MantissaBits))
+}
+
+// Converts a float64 to the nearest Float16, with ties rounded to even. Values too large for a Float16 become infinite.
+func Float16FromFloat64(f float64) Float16 {
+	return Float16(roundToHalf(f, float16ExponentBits, float16MantissaBits))
+}
+
+// Converts the Float16 to a float32. This is exact.
+func (h Float16) Float32() float32 {
+	sign := uint32(h>>15) << 31
+	exponent := uint32(h>>float16MantissaBits) & 0x1f
+	mantissa := uint32(h) & 0x3ff
+
+	switch {
+	case exponent == 0x1f:
+		// infinity or NaN
+		return math.Float32frombits(sign | 0xff<<23 | mantissa<<13)
+	case exponent != 0:
+		return math.Float32frombits(sign | (exponent-15+127)<<23 | mantissa<<13)
+	case mantissa == 0:
+		return math.Float32frombits(sign)
+	default:
+		// subnormal: the value is mantissa * 2^-24, which is a normal float32
+		value := float32(mantissa) * (1.0 / (1 << 24))
+		if sign != 0 {
+			value = -value
+		}
+
+		return value
+	}
+}
+
+// Converts the Float16 to a float64. This is exact.
+func (h Float16) Float64() float64 {
+	return float64(h.Float32())
+}
+
+// Returns the shortest representation that converts back to the same Float16.
+func (h Float16) String() string {
+	return formatHalf(h.Float64(), func(f float64) bool { return Float16FromFloat64(f) == h })
+}
+
+// Implements json.Marshaler, encoding the value as a JSON number.
+func (h Float16) MarshalJSON() ([]byte, error) {
+	if f := h.Float64(); math.IsNaN(f) || math.IsInf(f, 0) {
+		// fails like it does for other floats
+		return json.Marshal(f)
+	}
+
+	return []byte(h.String()), nil
+}
+
+// Implements json.Unmarshaler.
+func (h *Float16) UnmarshalJSON(b []byte) error {
+	var f float64
+	if err := json.Unmarshal(b, &f); err != nil {
+		return err
+	}
+
+	*h = Float16FromFloat64(f)
+	return nil
+}
+
+// Converts a float32 to the nearest BFloat16, with ties rounded to even.
+func BFloat16FromFloat32(f float32) BFloat16 {
+	return BFloat16(roundToHalf(float64(f), bfloat16ExponentBits, bfloat16MantissaBits))
+}
+
+// Converts a float64 to the nearest BFloat16, with ties rounded to even.
+func BFloat16FromFloat64(f float64) BFloat16 {
+	return BFloat16(roundToHalf(f, bfloat16ExponentBits, bfloat16MantissaBits))
+}
+
+// Converts the BFloat16 to a float32. This is exact.
+func (b BFloat16) Float32() float32 {
+	return math.Float32frombits(uint32(b) << 16)
+}
+
+// Converts the BFloat16 to a float64. This is exact.
+func (b BFloat16) Float64() float64 {
+	return float64(b.Float32())
+}
+
+// Returns the shortest representation that converts back to the same BFloat16.
+func (b BFloat16) String() string {
+	return formatHalf(b.Float64(), func(f float64) bool { return BFloat16FromFloat64(f) == b })
+}
+
+// Implements json.Marshaler, encoding the value as a JSON number.
+func (b BFloat16) MarshalJSON() ([]byte, error) {
+	if f := b.Float64(); math.IsNaN(f) || math.IsInf(f, 0) {
+		// fails like it does for other floats
+		return json.Marshal(f)
+	}
+
+	return []byte(b.String()), nil
+}
+
+// Implements json.Unmarshaler.
+func (b *BFloat16) UnmarshalJSON(data []byte) error {
+	var f float64
+	if err := json.Unmarshal(data, &f); err != nil {
+		return err
+	}
+
+	*b = BFloat16FromFloat64(f)
+	return nil
+}
+
+// Formats a half-precision value with as few significant digits as possible, such that roundTrips() reports that the
+// result parses back to the same value.
+func formatHalf(value float64, roundTrips func(f float64) bool) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+
+	for digits := 1; digits < 17; digits++ {
+		short, _ := strconv.ParseFloat(strconv.FormatFloat(value, 'e', digits-1, 64), 64)
+		if roundTrips(short) {
+			// formatting the short value as a float64 gives the same digits, in the notation that strconv would use
+			return strconv.FormatFloat(short, 'g', -1, 64)
+		}
+	}
+
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
+
+// Rounds a float64 to the nearest 16-bit float with the given number of exponent & mantissa bits, with ties rounded to
+// even, and returns its bits. Rounding directly from float64 avoids the double rounding of going through float32.
+func roundToHalf(f float64, exponentBits, mantissaBits uint) uint16 {
+	bits := math.Float64bits(f)
+	sign := uint16(bits>>63) << 15
+	exponent := int(bits>>52) & 0x7ff
+	mantissa := bits & (1<<52 - 1)
+
+	bias := 1<<(exponentBits-1) - 1
+	maxExponent := 1<<exponentBits - 1
+	infinity := sign | uint16(maxExponent)<<mantissaBits
+
+	switch {
+	case exponent == 0x7ff && mantissa != 0:
+		// quiet NaN
+		return infinity | 1<<(mantissaBits-1)
+	case exponent == 0x7ff:
+		return infinity
+	case exponent == 0 && mantissa == 0:
+		return sign
+	}
+
+	// the value is significand * 2^(unbiased - 52)
+	unbiased, significand := exponent-1023, mantissa|1<<52
+	if exponent == 0 {
+		unbiased, significand = -1022, mantissa
+	}
+
+	halfExponent := unbiased + bias
+	if halfExponent >= maxExponent {
+		return infinity
+	}
+
+	// drop the bits that don't fit in the mantissa, plus the ones below the smallest subnormal if it's one
+	shift := 52 - int(mantissaBits)
+	if halfExponent <= 0 {
+		shift += 1 - halfExponent
+	}
+
+	if shift > 63 {
+		return sign
+	}
+
+	rounded := significand >> shift
+	remainder := significand & (1<<shift - 1)
+	halfway := uint64(1) << (shift - 1)
+	if remainder > halfway || (remainder == halfway && rounded&1 == 1) {
+		rounded++
+	}
+
+	if halfExponent <= 0 {
+		// subnormal, or the smallest normal if rounding carried into the exponent
+		return sign | uint16(rounded)
+	}
+
+	// rounded includes the implicit leading 1, which adds 1 to the exponent, so a carry from rounding the mantissa
+	// correctly bumps the exponent too
+	result := uint64(halfExponent-1)<<mantissaBits + rounded
+	if result >= uint64(maxExponent)<<mantissaBits {
+		return infinity
+	}
+
+	return sign | uint16(result)
+}
+
+// Checks if T is one of the half-precision types, whose operators work on the underlying bits rather than the values.
+func isHalf[T Scalar]() bool {
+	var zero T
+	switch any(zero).(type) {
+	case Float16, BFloat16:
+		return true
+	default:
+		return false
+	}
+}
+
+// Converts a float64 to T, rounding it to the nearest value for half-precision types.
+func fromFloat64[T Scalar](f float64) T {
+	var zero T
+	switch any(zero).(type) {
+	case Float16:
+		return T(Float16FromFloat64(f))
+	case BFloat16:
+		return T(BFloat16FromFloat64(f))
+	default:
+		return T(f)
+	}
+}
+
+// Converts the elements of a slice to another Scalar type. Half-precision values are converted through float64, which
+// represents all of them exactly.
+func castSlice[To Scalar, From Scalar](values []From) []To {
+	result := make([]To, len(values))
+	if !isHalf[From]() && !isHalf[To]() {
+		for i, value := range values {
+			result[i] = To(value)
+		}
+
+		return result
+	}
+
+	for i, value := range values {
+		result[i] = fromFloat64[To](toFloat64(value))
+	}
+
+	return result
+}
+
+// Returns a copy of the tensor with its elements converted to To, like a Go conversion of each element. Conversions to
+// Float16 & BFloat16 round to the nearest value.
+//
+// For example, Cast[Float16](weights) halves the memory used by float32 weights.
+func Cast[To Scalar, From Scalar](t *Tensor[From]) *Tensor[To] {
+	shape := make([]uint, len(t.shape))
+	copy(shape, t.shape)
+
+	// the elements are converted in place, so the result has the same layout
+	result := fromData(shape, castSlice[To](t.data))
+	result.strides = slices.Clone(t.strides)
+	return result
+}
+
+// Converts tensors to float32, so that operations on half-precision tensors can be done in float32 & rounded back with
+// Cast[T]().
+func upcastAll[T Scalar](tensors []*Tensor[T]) []*Tensor[float32] {
+	result := make([]*Tensor[float32], len(tensors))
+	for i, t := range tensors {
+		result[i] = Cast[float32](t)
+	}
+
+	return result
+}
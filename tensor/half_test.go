@@ -0,0 +1,240 @@
+package tensor
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestFloat16Conversion(t *testing.T) {
+	testCases := []struct {
+		value    float64
+		expected Float16
+	}{
+		{1, 0x3c00},
+		{-2, 0xc000},
+		{math.Copysign(0, -1), 0x8000},
+		{65504, 0x7bff},
+		{65520, 0x7c00}, // halfway to 65536, rounded to the even infinity
+		{math.Inf(-1), 0xfc00},
+		{math.Ldexp(1, -24), 0x0001},
+		{math.Ldexp(1, -25), 0x0000},                          // halfway to the smallest subnormal, rounded to even
+		{math.Ldexp(3, -26), 0x0001},                          // above halfway
+		{1 + math.Ldexp(1, -11), 0x3c00},                      // halfway, rounded to even
+		{1 + math.Ldexp(3, -11), 0x3c02},                      // halfway, rounded to even
+		{1 + math.Ldexp(1, -11) + math.Ldexp(1, -40), 0x3c01}, // would be a tie if it was rounded to float32 first
+	}
+
+	for _, tc := range testCases {
+		if result := Float16FromFloat64(tc.value); result != tc.expected {
+			t.Errorf("Float16FromFloat64(%v): expected %#04x, got %#04x", tc.value, uint16(tc.expected), uint16(result))
+		}
+	}
+
+	if nan := Float16FromFloat32(float32(math.NaN())); !math.IsNaN(nan.Float64()) {
+		t.Errorf("expected NaN, got %v", nan)
+	}
+
+	// every value converts to float32 & back exactly
+	for bits := 0; bits <= math.MaxUint16; bits++ {
+		h := Float16(bits)
+		if f := h.Float32(); !math.IsNaN(float64(f)) && Float16FromFloat32(f) != h {
+			t.Fatalf("%#04x: converted to %v & back to %#04x", bits, f, uint16(Float16FromFloat32(f)))
+		}
+	}
+}
+
+func TestBFloat16Conversion(t *testing.T) {
+	testCases := []struct {
+		bits     uint32
+		expected BFloat16
+	}{
+		{0x3f800000, 0x3f80},
+		{0x3f808000, 0x3f80}, // halfway, rounded to even
+		{0x3f818000, 0x3f82}, // halfway, rounded to even
+		{0x3f808001, 0x3f81},
+		{0x7f7fffff, 0x7f80}, // the largest float32 rounds to infinity
+	}
+
+	for _, tc := range testCases {
+		if result := BFloat16FromFloat32(math.Float32frombits(tc.bits)); result != tc.expected {
+			t.Errorf("BFloat16FromFloat32(%#08x): expected %#04x, got %#04x", tc.bits, uint16(tc.expected), uint16(result))
+		}
+	}
+
+	for bits := 0; bits <= math.MaxUint16; bits++ {
+		b := BFloat16(bits)
+		if f := b.Float32(); !math.IsNaN(float64(f)) && BFloat16FromFloat32(f) != b {
+			t.Fatalf("%#04x: converted to %v & back to %#04x", bits, f, uint16(BFloat16FromFloat32(f)))
+		}
+	}
+}
+
+func TestHalfString(t *testing.T) {
+	if s := Float16FromFloat64(0.1).String(); s != "0.1" {
+		t.Errorf("expected 0.1, got %s", s)
+	}
+
+	if s := BFloat16FromFloat64(3.14159).String(); s != "3.14" {
+		t.Errorf("expected 3.14, got %s", s)
+	}
+
+	// like NumPy, the shortest representation of 65504 is 65500
+	tensor := Cast[Float16](WithValue[float64]([]float64{0.1, -2, 65504}))
+	if s := tensor.String(); s != "Tensor([  0.1    -2 65500])" {
+		t.Errorf("unexpected String() %q", s)
+	}
+}
+
+func TestHalfArithmetic(t *testing.T) {
+	t1 := Cast[Float16](WithValue[float32]([][]float32{{1, 2.5}, {-3, 0.25}}))
+	t2 := Cast[Float16](WithValue[float32]([][]float32{{0.5, -1}, {2, 4}}))
+
+	sum := Add(t1, t2)
+	expected := WithValue[float32]([][]float32{{1.5, 1.5}, {-1, 4.25}})
+	if !reflect.DeepEqual(expected, Cast[float32](sum)) {
+		t.Fatalf("Add(): expected %v, got %v", expected, sum)
+	}
+
+	product := MatrixMultiplication(t1, t2)
+	expected = WithValue[float32]([][]float32{{5.5, 9}, {-1, 4}})
+	if !reflect.DeepEqual(expected, Cast[float32](product)) {
+		t.Fatalf("MatrixMultiplication(): expected %v, got %v", expected, product)
+	}
+
+	sorted := Sort(Cast[BFloat16](WithValue[float32]([]float32{2, -1, 0.5, -3})), 0)
+	expectedSorted := WithValue[float32]([]float32{-3, -1, 0.5, 2})
+	if !reflect.DeepEqual(expectedSorted, Cast[float32](sorted)) {
+		t.Fatalf("Sort(): expected %v, got %v", expectedSorted, sorted)
+	}
+}
+
+func TestHalfEncoding(t *testing.T) {
+	tensor := Cast[Float16](WithValue[float64]([][]float64{{0.1, -2}, {1e-7, 65504}}))
+
+	b, err := tensor.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): unexpected error %v", err)
+	}
+
+	var decoded Tensor[Float16]
+	if err := decoded.UnmarshalBinary(b); err != nil || !reflect.DeepEqual(tensor, &decoded) {
+		t.Fatalf("UnmarshalBinary(): expected %v, got %v (error %v)", tensor, &decoded, err)
+	}
+
+	var wrongType Tensor[uint16]
+	if err := wrongType.UnmarshalBinary(b); err == nil {
+		t.Fatal("UnmarshalBinary(): expected an error for uint16")
+	}
+
+	// F16 safetensors can be loaded as float32
+	var buf bytes.Buffer
+	if err := SaveSafetensors(&buf, map[string]*Tensor[Float16]{"weights": tensor}, nil); err != nil {
+		t.Fatalf("SaveSafetensors(): unexpected error %v", err)
+	}
+
+	loaded, _, err := LoadSafetensors[float32](&buf)
+	if err != nil {
+		t.Fatalf("LoadSafetensors(): unexpected error %v", err)
+	}
+
+	if expected := Cast[float32](tensor); !reflect.DeepEqual(expected, loaded["weights"]) {
+		t.Fatalf("LoadSafetensors(): expected %v, got %v", expected, loaded["weights"])
+	}
+}
+
+// Calls the exported functions that compute with the values of their operands, for a & b of shape [2, 2].
+func halfEntryPoints[T FloatScalar | HalfScalar](a, b *Tensor[T]) map[string]*Tensor[T] {
+	images := a.Reshape(1, 1, 2, 2)
+	kernel := b.Reshape(1, 1, 2, 2)
+	topK, _ := TopK(a, 1, 1)
+	unique, _ := Unique(a)
+	gradInput, gradWeight := ConvBackward(WithShape[T]([]uint{1, 1, 1, 1}, a.Get(0, 0)), images, kernel, ConvOptions{})
+
+	return map[string]*Tensor[T]{
+		"Add":                  Add(a, b),
+		"Subtract":             Subtract(a, b),
+		"Multiply":             Multiply(a, b),
+		"Divide":               Divide(a, b),
+		"MatrixMultiplication": MatrixMultiplication(a, b),
+		"Sum":                  Sum(a, 0),
+		"Prod":                 Prod(a, 1),
+		"Max":                  Max(a, 0),
+		"Min":                  Min(a, 1),
+		"CumSum":               CumSum(a, 1),
+		"CumProd":              CumProd(a, 0),
+		"CumMax":               CumMax(a, 1),
+		"CumMin":               CumMin(a, 0),
+		"Diff":                 Diff(a, 1, 1),
+		"Sort":                 Sort(a, 1),
+		"Partition":            Partition(b, 0, 0),
+		"TopK":                 topK,
+		"Unique":               unique,
+		"Pad":                  Pad(a, [][2]uint{{1, 1}}, PadReflect),
+		"Einsum":               Einsum("ij,jk->ik", a, b),
+		"Conv":                 Conv(images, kernel, ConvOptions{Padding: []int{1, 1}}),
+		"ConvBackward input":   gradInput,
+		"ConvBackward weight":  gradWeight,
+		"MaxPool":              MaxPool(images, PoolOptions{KernelSize: []uint{2, 1}}),
+		"AvgPool":              AvgPool(images, PoolOptions{KernelSize: []uint{1, 2}}),
+		"NanSum":               NanSum(a, 1),
+		"NanMean":              NanMean(a, 0),
+		"NanStd":               NanStd(b, 1),
+		"NanMax":               NanMax(a, 1),
+		"Dequantize(Quantize)": Dequantize[T](Quantize[uint8](a)),
+		"SparseMatMul":         SparseMatMul(SparseFromDense(a), b),
+		"SparseAdd":            SparseAdd(SparseFromDense(a), SparseFromDense(b)).ToDense(),
+	}
+}
+
+// Calls the exported functions that compare the values of their operands.
+func halfComparisons[T FloatScalar | HalfScalar](a, b *Tensor[T]) map[string]any {
+	_, topK := TopK(a, 1, 0)
+	_, counts := Unique(a)
+
+	return map[string]any{
+		"ArgSort":       ArgSort(a, 1),
+		"ArgSortStable": ArgSortStable(b, 0),
+		"ArgPartition":  ArgPartition(a, 1, 1),
+		"TopK":          topK,
+		"SearchSorted":  SearchSorted(Sort(a.Reshape(4), 0), b, SideLeft),
+		"NanArgMax":     NanArgMax(b, 1),
+		"Unique":        counts,
+		"Equal":         Equal(a, b),
+		"AllClose":      AllClose(a, a),
+		"IsClose":       IsClose(a, b),
+		"IsFinite":      IsFinite(a),
+		"NNZ":           SparseFromDense(a).NNZ(),
+	}
+}
+
+func TestHalfEntryPoints(t *testing.T) {
+	checkHalfEntryPoints[Float16](t)
+	checkHalfEntryPoints[BFloat16](t)
+}
+
+// Checks that the functions give the same results for H as for float32 rounded to H, i.e. that they compute in float32
+// rather than on the bits.
+func checkHalfEntryPoints[H HalfScalar](t *testing.T) {
+	t.Helper()
+
+	// -0 has non-zero bits, so it's only skipped by SparseFromDense() if compared as a value
+	a := Cast[H](WithValue[float32]([][]float32{{1, 2.5}, {-3, float32(math.Copysign(0, -1))}}))
+	b := Cast[H](WithValue[float32]([][]float32{{0.5, -1}, {2, 4}}))
+	a32, b32 := Cast[float32](a), Cast[float32](b)
+
+	got, expected := halfEntryPoints(a, b), halfEntryPoints(a32, b32)
+	for name, result := range got {
+		if want := Cast[float32](Cast[H](expected[name])); !reflect.DeepEqual(want, Cast[float32](result)) {
+			t.Errorf("%T: %s(): expected %v, got %v", a, name, want, Cast[float32](result))
+		}
+	}
+
+	gotComparisons, expectedComparisons := halfComparisons(a, b), halfComparisons(a32, b32)
+	for name, result := range gotComparisons {
+		if !reflect.DeepEqual(expectedComparisons[name], result) {
+			t.Errorf("%T: %s(): expected %v, got %v", a, name, expectedComparisons[name], result)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package simd
+
+// The portable kernels. They mirror the lanes of the AVX2 kernels, so that both return the same results: dot products
+// use 8 float64 or 16 float32 partial sums that are reduced pairwise in the same order.
+//
+// Products are explicitly converted before being added, because the Go spec allows x*y + z to be fused into a single
+// instruction otherwise, which would round differently.
+
+func dotFloat64Generic(x, y []float64) float64 {
+	var acc [8]float64
+	n := len(x) &^ 7
+	for i := 0; i < n; i += 8 {
+		xs, ys := x[i:i+8], y[i:i+8]
+		for j := range acc {
+			acc[j] += float64(xs[j] * ys[j])
+		}
+	}
+
+	// lanes j & j+4 first, then the two halves, then the two remaining sums
+	lane0, lane1, lane2, lane3 := acc[0]+acc[4], acc[1]+acc[5], acc[2]+acc[6], acc[3]+acc[7]
+	sum := (lane0 + lane2) + (lane1 + lane3)
+	for i := n; i < len(x); i++ {
+		sum += float64(x[i] * y[i])
+	}
+
+	return sum
+}
+
+func dotFloat32Generic(x, y []float32) float32 {
+	var acc [16]float32
+	n := len(x) &^ 15
+	for i := 0; i < n; i += 16 {
+		xs, ys := x[i:i+16], y[i:i+16]
+		for j := range acc {
+			acc[j] += float32(xs[j] * ys[j])
+		}
+	}
+
+	var lanes [8]float32
+	for j := range lanes {
+		lanes[j] = acc[j] + acc[j+8]
+	}
+
+	var quarters [4]float32
+	for j := range quarters {
+		quarters[j] = lanes[j] + lanes[j+4]
+	}
+
+	sum := (quarters[0] + quarters[2]) + (quarters[1] + quarters[3])
+	for i := n; i < len(x); i++ {
+		sum += float32(x[i] * y[i])
+	}
+
+	return sum
+}
+
+func axpyFloat64Generic(alpha float64, x, y []float64) {
+	for i, value := range x {
+		y[i] += float64(alpha * value)
+	}
+}
+
+func axpyFloat32Generic(alpha float32, x, y []float32) {
+	for i, value := range x {
+		y[i] += float32(alpha * value)
+	}
+}
+
+func addFloat64Generic(dst, x, y []float64) {
+	for i := range dst {
+		dst[i] = x[i] + y[i]
+	}
+}
+
+func addFloat32Generic(dst, x, y []float32) {
+	for i := range dst {
+		dst[i] = x[i] + y[i]
+	}
+}
+
+func mulFloat64Generic(dst, x, y []float64) {
+	for i := range dst {
+		dst[i] = x[i] * y[i]
+	}
+}
+
+func mulFloat32Generic(dst, x, y []float32) {
+	for i := range dst {
+		dst[i] = x[i] * y[i]
+	}
+}
+
+// Computes the 4x8 block of c at its start as the product of the 4 rows of a & the 8 columns of b at their starts.
+// Rows are lda, ldb & ldc elements apart.
+func matMulBlockFloat64Generic(a, b, c []float64, lda, ldb, ldc, k int) {
+	var acc [4][8]float64
+	for p := 0; p < k; p++ {
+		row := b[p*ldb : p*ldb+8]
+		for i := range acc {
+			alpha := a[i*lda+p]
+			for j, value := range row {
+				acc[i][j] += float64(alpha * value)
+			}
+		}
+	}
+
+	for i := range acc {
+		copy(c[i*ldc:i*ldc+8], acc[i][:])
+	}
+}
+
+// Computes the 4x16 block of c at its start as the product of the 4 rows of a & the 16 columns of b at their starts.
+// Rows are lda, ldb & ldc elements apart.
+func matMulBlockFloat32Generic(a, b, c []float32, lda, ldb, ldc, k int) {
+	var acc [4][16]float32
+	for p := 0; p < k; p++ {
+		row := b[p*ldb : p*ldb+16]
+		for i := range acc {
+			alpha := a[i*lda+p]
+			for j, value := range row {
+				acc[i][j] += float32(alpha * value)
+			}
+		}
+	}
+
+	for i := range acc {
+		copy(c[i*ldc:i*ldc+16], acc[i][:])
+	}
+}
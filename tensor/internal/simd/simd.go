@@ -0,0 +1,156 @@
+// Package simd provides the float32 & float64 kernels behind the hot paths of the tensor package: dot products, AXPY,
+// elementwise addition & multiplication and matrix multiplication.
+//
+// On amd64 CPUs with AVX2, the kernels are written in assembly. Everywhere else, or when built with the purego tag, they
+// are written in plain Go. Both compute every result with the same operations in the same order, and never fuse a
+// multiplication with an addition, so they return bit-identical results.
+//
+// There is no arm64 assembly: arm64 uses the plain Go kernels, which keep independent partial sums & blocks of the
+// result in local arrays so that the compiler can keep them in registers. The *Generic benchmarks measure these
+// kernels on any machine, so they show the arm64 path even when run on amd64.
+package simd
+
+import "fmt"
+
+// The kernels used by the exported functions, replaced by the accelerated ones at init if the CPU supports them.
+var (
+	dotFloat64 = dotFloat64Generic
+	dotFloat32 = dotFloat32Generic
+
+	axpyFloat64 = axpyFloat64Generic
+	axpyFloat32 = axpyFloat32Generic
+
+	addFloat64 = addFloat64Generic
+	addFloat32 = addFloat32Generic
+
+	mulFloat64 = mulFloat64Generic
+	mulFloat32 = mulFloat32Generic
+
+	matMulBlockFloat64 = matMulBlockFloat64Generic
+	matMulBlockFloat32 = matMulBlockFloat32Generic
+)
+
+// Set to true at init if the accelerated kernels are used.
+var accelerated = false
+
+// Reports whether the kernels are accelerated on this machine.
+func Accelerated() bool {
+	return accelerated
+}
+
+func checkLengths(lengths ...int) {
+	for _, length := range lengths[1:] {
+		if length != lengths[0] {
+			panic(fmt.Sprintf("simd: slices of different lengths %v", lengths))
+		}
+	}
+}
+
+// Returns the sum of x[i] * y[i].
+func DotFloat64(x, y []float64) float64 {
+	checkLengths(len(x), len(y))
+	return dotFloat64(x, y)
+}
+
+// Returns the sum of x[i] * y[i].
+func DotFloat32(x, y []float32) float32 {
+	checkLengths(len(x), len(y))
+	return dotFloat32(x, y)
+}
+
+// Computes y[i] += alpha * x[i].
+func AxpyFloat64(alpha float64, x, y []float64) {
+	checkLengths(len(x), len(y))
+	axpyFloat64(alpha, x, y)
+}
+
+// Computes y[i] += alpha * x[i].
+func AxpyFloat32(alpha float32, x, y []float32) {
+	checkLengths(len(x), len(y))
+	axpyFloat32(alpha, x, y)
+}
+
+// Computes dst[i] = x[i] + y[i].
+func AddFloat64(dst, x, y []float64) {
+	checkLengths(len(dst), len(x), len(y))
+	addFloat64(dst, x, y)
+}
+
+// Computes dst[i] = x[i] + y[i].
+func AddFloat32(dst, x, y []float32) {
+	checkLengths(len(dst), len(x), len(y))
+	addFloat32(dst, x, y)
+}
+
+// Computes dst[i] = x[i] * y[i].
+func MulFloat64(dst, x, y []float64) {
+	checkLengths(len(dst), len(x), len(y))
+	mulFloat64(dst, x, y)
+}
+
+// Computes dst[i] = x[i] * y[i].
+func MulFloat32(dst, x, y []float32) {
+	checkLengths(len(dst), len(x), len(y))
+	mulFloat32(dst, x, y)
+}
+
+// Computes the row-major matrix product c = a * b, where a is m x k, b is k x n & m = len(c) / n. Every element is
+// summed in the order of k like with the naive algorithm, but blocks of 4x8 elements of c are kept in registers.
+func MatMulFloat64(a, b, c []float64, k, n int) {
+	checkMatMul(len(a), len(b), len(c), k, n)
+	matMul(a, b, c, k, n, 8, matMulBlockFloat64)
+}
+
+// Computes the row-major matrix product c = a * b, where a is m x k, b is k x n & m = len(c) / n. Every element is
+// summed in the order of k like with the naive algorithm, but blocks of 4x16 elements of c are kept in registers.
+func MatMulFloat32(a, b, c []float32, k, n int) {
+	checkMatMul(len(a), len(b), len(c), k, n)
+	matMul(a, b, c, k, n, 16, matMulBlockFloat32)
+}
+
+func checkMatMul(lenA, lenB, lenC, k, n int) {
+	if k < 0 || n < 0 || lenB != k*n || (n > 0 && (lenC%n != 0 || lenA != lenC/n*k)) || (n == 0 && lenC != 0) {
+		panic(fmt.Sprintf("simd: matrices of %d, %d & %d elements don't match k = %d & n = %d", lenA, lenB, lenC, k, n))
+	}
+}
+
+// Number of rows of the blocks computed by the matrix multiplication kernels.
+const blockRows = 4
+
+// Computes c = a * b with the kernel for the full blocks of blockRows x blockCols elements, and the naive algorithm
+// for the remaining rows & columns.
+func matMul[F float32 | float64](a, b, c []F, k, n, blockCols int, block func(a, b, c []F, lda, ldb, ldc, k int)) {
+	if n == 0 {
+		return
+	}
+
+	if k == 0 {
+		clear(c)
+		return
+	}
+
+	m := len(c) / n
+	fullRows, fullCols := m-m%blockRows, n-n%blockCols
+	for i := 0; i < fullRows; i += blockRows {
+		for j := 0; j < fullCols; j += blockCols {
+			block(a[i*k:], b[j:], c[i*n+j:], k, n, n, k)
+		}
+	}
+
+	matMulNaive(a, b, c, k, n, 0, fullRows, fullCols, n)
+	matMulNaive(a, b, c, k, n, fullRows, m, 0, n)
+}
+
+// Computes the elements of c = a * b in the given rows & columns.
+func matMulNaive[F float32 | float64](a, b, c []F, k, n, rowStart, rowEnd, colStart, colEnd int) {
+	for i := rowStart; i < rowEnd; i++ {
+		for j := colStart; j < colEnd; j++ {
+			var sum F
+			for p := 0; p < k; p++ {
+				sum += F(a[i*k+p] * b[p*n+j])
+			}
+
+			c[i*n+j] = sum
+		}
+	}
+}
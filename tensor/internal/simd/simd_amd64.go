@@ -0,0 +1,54 @@
+//go:build amd64 && !purego
+
+package simd
+
+func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+func xgetbv() (eax, edx uint32)
+
+func dotFloat64AVX2(x, y []float64) float64
+func dotFloat32AVX2(x, y []float32) float32
+func axpyFloat64AVX2(alpha float64, x, y []float64)
+func axpyFloat32AVX2(alpha float32, x, y []float32)
+func addFloat64AVX2(dst, x, y []float64)
+func addFloat32AVX2(dst, x, y []float32)
+func mulFloat64AVX2(dst, x, y []float64)
+func mulFloat32AVX2(dst, x, y []float32)
+func matMulBlockFloat64AVX2(a, b, c []float64, lda, ldb, ldc, k int)
+func matMulBlockFloat32AVX2(a, b, c []float32, lda, ldb, ldc, k int)
+
+func init() {
+	if !hasAVX2() {
+		return
+	}
+
+	dotFloat64, dotFloat32 = dotFloat64AVX2, dotFloat32AVX2
+	axpyFloat64, axpyFloat32 = axpyFloat64AVX2, axpyFloat32AVX2
+	addFloat64, addFloat32 = addFloat64AVX2, addFloat32AVX2
+	mulFloat64, mulFloat32 = mulFloat64AVX2, mulFloat32AVX2
+	matMulBlockFloat64, matMulBlockFloat32 = matMulBlockFloat64AVX2, matMulBlockFloat32AVX2
+	accelerated = true
+}
+
+// Checks that both the CPU & the OS support AVX2, i.e. that the OS saves the YMM registers.
+func hasAVX2() bool {
+	maxLeaf, _, _, _ := cpuid(0, 0)
+	if maxLeaf < 7 {
+		return false
+	}
+
+	_, _, ecx1, _ := cpuid(1, 0)
+	hasAVX := ecx1&(1<<28) != 0
+	hasOSXSAVE := ecx1&(1<<27) != 0
+	if !hasAVX || !hasOSXSAVE {
+		return false
+	}
+
+	// the XMM & YMM state must be enabled in XCR0
+	xcr0, _ := xgetbv()
+	if xcr0&0b110 != 0b110 {
+		return false
+	}
+
+	_, ebx7, _, _ := cpuid(7, 0)
+	return ebx7&(1<<5) != 0
+}
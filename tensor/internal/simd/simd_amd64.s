@@ -0,0 +1,496 @@
+//go:build amd64 && !purego
+
+#include "textflag.h"
+
+// func cpuid(eaxArg, ecxArg uint32) (eax, ebx, ecx, edx uint32)
+TEXT ·cpuid(SB), NOSPLIT, $0-24
+	MOVL eaxArg+0(FP), AX
+	MOVL ecxArg+4(FP), CX
+	CPUID
+	MOVL AX, eax+8(FP)
+	MOVL BX, ebx+12(FP)
+	MOVL CX, ecx+16(FP)
+	MOVL DX, edx+20(FP)
+	RET
+
+// func xgetbv() (eax, edx uint32)
+TEXT ·xgetbv(SB), NOSPLIT, $0-8
+	MOVL $0, CX
+	XGETBV
+	MOVL AX, eax+0(FP)
+	MOVL DX, edx+4(FP)
+	RET
+
+// func dotFloat64AVX2(x, y []float64) float64
+//
+// Y0 & Y1 hold 8 partial sums, reduced like dotFloat64Generic.
+TEXT ·dotFloat64AVX2(SB), NOSPLIT, $0-56
+	MOVQ x_base+0(FP), SI
+	MOVQ x_len+8(FP), CX
+	MOVQ y_base+24(FP), DI
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	MOVQ CX, BX
+	SHRQ $3, BX
+	JZ   dot64reduce
+
+dot64loop:
+	VMOVUPD (SI), Y2
+	VMOVUPD 32(SI), Y3
+	VMULPD  (DI), Y2, Y2
+	VMULPD  32(DI), Y3, Y3
+	VADDPD  Y2, Y0, Y0
+	VADDPD  Y3, Y1, Y1
+	ADDQ    $64, SI
+	ADDQ    $64, DI
+	DECQ    BX
+	JNZ     dot64loop
+
+dot64reduce:
+	VADDPD       Y1, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPD       X1, X0, X0
+	VUNPCKHPD    X0, X0, X1
+	VADDSD       X1, X0, X0
+	ANDQ         $7, CX
+	JZ           dot64done
+
+dot64tail:
+	VMOVSD (SI), X2
+	VMULSD (DI), X2, X2
+	VADDSD X2, X0, X0
+	ADDQ   $8, SI
+	ADDQ   $8, DI
+	DECQ   CX
+	JNZ    dot64tail
+
+dot64done:
+	VZEROUPPER
+	MOVSD X0, ret+48(FP)
+	RET
+
+// func dotFloat32AVX2(x, y []float32) float32
+//
+// Y0 & Y1 hold 16 partial sums, reduced like dotFloat32Generic.
+TEXT ·dotFloat32AVX2(SB), NOSPLIT, $0-52
+	MOVQ x_base+0(FP), SI
+	MOVQ x_len+8(FP), CX
+	MOVQ y_base+24(FP), DI
+	VXORPS Y0, Y0, Y0
+	VXORPS Y1, Y1, Y1
+	MOVQ CX, BX
+	SHRQ $4, BX
+	JZ   dot32reduce
+
+dot32loop:
+	VMOVUPS (SI), Y2
+	VMOVUPS 32(SI), Y3
+	VMULPS  (DI), Y2, Y2
+	VMULPS  32(DI), Y3, Y3
+	VADDPS  Y2, Y0, Y0
+	VADDPS  Y3, Y1, Y1
+	ADDQ    $64, SI
+	ADDQ    $64, DI
+	DECQ    BX
+	JNZ     dot32loop
+
+dot32reduce:
+	VADDPS       Y1, Y0, Y0
+	VEXTRACTF128 $1, Y0, X1
+	VADDPS       X1, X0, X0
+	VMOVHLPS     X0, X0, X1
+	VADDPS       X1, X0, X0
+	VMOVSHDUP    X0, X1
+	VADDSS       X1, X0, X0
+	ANDQ         $15, CX
+	JZ           dot32done
+
+dot32tail:
+	VMOVSS (SI), X2
+	VMULSS (DI), X2, X2
+	VADDSS X2, X0, X0
+	ADDQ   $4, SI
+	ADDQ   $4, DI
+	DECQ   CX
+	JNZ    dot32tail
+
+dot32done:
+	VZEROUPPER
+	MOVSS X0, ret+48(FP)
+	RET
+
+// func axpyFloat64AVX2(alpha float64, x, y []float64)
+TEXT ·axpyFloat64AVX2(SB), NOSPLIT, $0-56
+	VBROADCASTSD alpha+0(FP), Y0
+	MOVQ         x_base+8(FP), SI
+	MOVQ         x_len+16(FP), CX
+	MOVQ         y_base+32(FP), DI
+	MOVQ         CX, BX
+	SHRQ         $2, BX
+	JZ           axpy64tailcheck
+
+axpy64loop:
+	VMULPD  (SI), Y0, Y1
+	VADDPD  (DI), Y1, Y1
+	VMOVUPD Y1, (DI)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	DECQ    BX
+	JNZ     axpy64loop
+
+axpy64tailcheck:
+	ANDQ $3, CX
+	JZ   axpy64done
+
+axpy64tail:
+	VMOVSD (SI), X1
+	VMULSD X0, X1, X1
+	VADDSD (DI), X1, X1
+	VMOVSD X1, (DI)
+	ADDQ   $8, SI
+	ADDQ   $8, DI
+	DECQ   CX
+	JNZ    axpy64tail
+
+axpy64done:
+	VZEROUPPER
+	RET
+
+// func axpyFloat32AVX2(alpha float32, x, y []float32)
+TEXT ·axpyFloat32AVX2(SB), NOSPLIT, $0-56
+	VBROADCASTSS alpha+0(FP), Y0
+	MOVQ         x_base+8(FP), SI
+	MOVQ         x_len+16(FP), CX
+	MOVQ         y_base+32(FP), DI
+	MOVQ         CX, BX
+	SHRQ         $3, BX
+	JZ           axpy32tailcheck
+
+axpy32loop:
+	VMULPS  (SI), Y0, Y1
+	VADDPS  (DI), Y1, Y1
+	VMOVUPS Y1, (DI)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	DECQ    BX
+	JNZ     axpy32loop
+
+axpy32tailcheck:
+	ANDQ $7, CX
+	JZ   axpy32done
+
+axpy32tail:
+	VMOVSS (SI), X1
+	VMULSS X0, X1, X1
+	VADDSS (DI), X1, X1
+	VMOVSS X1, (DI)
+	ADDQ   $4, SI
+	ADDQ   $4, DI
+	DECQ   CX
+	JNZ    axpy32tail
+
+axpy32done:
+	VZEROUPPER
+	RET
+
+// func addFloat64AVX2(dst, x, y []float64)
+TEXT ·addFloat64AVX2(SB), NOSPLIT, $0-72
+	MOVQ dst_base+0(FP), DX
+	MOVQ dst_len+8(FP), CX
+	MOVQ x_base+24(FP), SI
+	MOVQ y_base+48(FP), DI
+	MOVQ CX, BX
+	SHRQ $2, BX
+	JZ   add64tailcheck
+
+add64loop:
+	VMOVUPD (SI), Y0
+	VADDPD (DI), Y0, Y0
+	VMOVUPD Y0, (DX)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	ADDQ    $32, DX
+	DECQ    BX
+	JNZ     add64loop
+
+add64tailcheck:
+	ANDQ $3, CX
+	JZ   add64done
+
+add64tail:
+	VMOVSD (SI), X0
+	VADDSD (DI), X0, X0
+	VMOVSD X0, (DX)
+	ADDQ   $8, SI
+	ADDQ   $8, DI
+	ADDQ   $8, DX
+	DECQ   CX
+	JNZ    add64tail
+
+add64done:
+	VZEROUPPER
+	RET
+
+// func addFloat32AVX2(dst, x, y []float32)
+TEXT ·addFloat32AVX2(SB), NOSPLIT, $0-72
+	MOVQ dst_base+0(FP), DX
+	MOVQ dst_len+8(FP), CX
+	MOVQ x_base+24(FP), SI
+	MOVQ y_base+48(FP), DI
+	MOVQ CX, BX
+	SHRQ $3, BX
+	JZ   add32tailcheck
+
+add32loop:
+	VMOVUPS (SI), Y0
+	VADDPS (DI), Y0, Y0
+	VMOVUPS Y0, (DX)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	ADDQ    $32, DX
+	DECQ    BX
+	JNZ     add32loop
+
+add32tailcheck:
+	ANDQ $7, CX
+	JZ   add32done
+
+add32tail:
+	VMOVSS (SI), X0
+	VADDSS (DI), X0, X0
+	VMOVSS X0, (DX)
+	ADDQ   $4, SI
+	ADDQ   $4, DI
+	ADDQ   $4, DX
+	DECQ   CX
+	JNZ    add32tail
+
+add32done:
+	VZEROUPPER
+	RET
+
+// func mulFloat64AVX2(dst, x, y []float64)
+TEXT ·mulFloat64AVX2(SB), NOSPLIT, $0-72
+	MOVQ dst_base+0(FP), DX
+	MOVQ dst_len+8(FP), CX
+	MOVQ x_base+24(FP), SI
+	MOVQ y_base+48(FP), DI
+	MOVQ CX, BX
+	SHRQ $2, BX
+	JZ   mul64tailcheck
+
+mul64loop:
+	VMOVUPD (SI), Y0
+	VMULPD (DI), Y0, Y0
+	VMOVUPD Y0, (DX)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	ADDQ    $32, DX
+	DECQ    BX
+	JNZ     mul64loop
+
+mul64tailcheck:
+	ANDQ $3, CX
+	JZ   mul64done
+
+mul64tail:
+	VMOVSD (SI), X0
+	VMULSD (DI), X0, X0
+	VMOVSD X0, (DX)
+	ADDQ   $8, SI
+	ADDQ   $8, DI
+	ADDQ   $8, DX
+	DECQ   CX
+	JNZ    mul64tail
+
+mul64done:
+	VZEROUPPER
+	RET
+
+// func mulFloat32AVX2(dst, x, y []float32)
+TEXT ·mulFloat32AVX2(SB), NOSPLIT, $0-72
+	MOVQ dst_base+0(FP), DX
+	MOVQ dst_len+8(FP), CX
+	MOVQ x_base+24(FP), SI
+	MOVQ y_base+48(FP), DI
+	MOVQ CX, BX
+	SHRQ $3, BX
+	JZ   mul32tailcheck
+
+mul32loop:
+	VMOVUPS (SI), Y0
+	VMULPS (DI), Y0, Y0
+	VMOVUPS Y0, (DX)
+	ADDQ    $32, SI
+	ADDQ    $32, DI
+	ADDQ    $32, DX
+	DECQ    BX
+	JNZ     mul32loop
+
+mul32tailcheck:
+	ANDQ $7, CX
+	JZ   mul32done
+
+mul32tail:
+	VMOVSS (SI), X0
+	VMULSS (DI), X0, X0
+	VMOVSS X0, (DX)
+	ADDQ   $4, SI
+	ADDQ   $4, DI
+	ADDQ   $4, DX
+	DECQ   CX
+	JNZ    mul32tail
+
+mul32done:
+	VZEROUPPER
+	RET
+
+// func matMulBlockFloat64AVX2(a, b, c []float64, lda, ldb, ldc, k int)
+//
+// Y0-Y7 hold the 4x8 block of c, 2 registers per row. Every step over k adds the products of a column of a with
+// a row of b, like matMulBlockFloat64Generic.
+TEXT ·matMulBlockFloat64AVX2(SB), NOSPLIT, $0-104
+	MOVQ   a_base+0(FP), SI
+	MOVQ   b_base+24(FP), DI
+	MOVQ   c_base+48(FP), DX
+	MOVQ   lda+72(FP), R8
+	MOVQ   ldb+80(FP), R10
+	MOVQ   ldc+88(FP), R11
+	MOVQ   k+96(FP), CX
+	SHLQ   $3, R8
+	SHLQ   $3, R10
+	SHLQ   $3, R11
+	LEAQ   (R8)(R8*2), R9
+	VXORPD Y0, Y0, Y0
+	VXORPD Y1, Y1, Y1
+	VXORPD Y2, Y2, Y2
+	VXORPD Y3, Y3, Y3
+	VXORPD Y4, Y4, Y4
+	VXORPD Y5, Y5, Y5
+	VXORPD Y6, Y6, Y6
+	VXORPD Y7, Y7, Y7
+	TESTQ  CX, CX
+	JZ     block64store
+
+block64loop:
+	VMOVUPD      (DI), Y8
+	VMOVUPD      32(DI), Y9
+
+	VBROADCASTSD (SI), Y10
+	VMULPD       Y8, Y10, Y11
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y11, Y0, Y0
+	VADDPD       Y12, Y1, Y1
+
+	VBROADCASTSD (SI)(R8*1), Y10
+	VMULPD       Y8, Y10, Y11
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y11, Y2, Y2
+	VADDPD       Y12, Y3, Y3
+
+	VBROADCASTSD (SI)(R8*2), Y10
+	VMULPD       Y8, Y10, Y11
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y11, Y4, Y4
+	VADDPD       Y12, Y5, Y5
+
+	VBROADCASTSD (SI)(R9*1), Y10
+	VMULPD       Y8, Y10, Y11
+	VMULPD       Y9, Y10, Y12
+	VADDPD       Y11, Y6, Y6
+	VADDPD       Y12, Y7, Y7
+
+	ADDQ         $8, SI
+	ADDQ         R10, DI
+	DECQ         CX
+	JNZ          block64loop
+
+block64store:
+	VMOVUPD    Y0, (DX)
+	VMOVUPD    Y1, 32(DX)
+	ADDQ       R11, DX
+	VMOVUPD    Y2, (DX)
+	VMOVUPD    Y3, 32(DX)
+	ADDQ       R11, DX
+	VMOVUPD    Y4, (DX)
+	VMOVUPD    Y5, 32(DX)
+	ADDQ       R11, DX
+	VMOVUPD    Y6, (DX)
+	VMOVUPD    Y7, 32(DX)
+	VZEROUPPER
+	RET
+
+// func matMulBlockFloat32AVX2(a, b, c []float32, lda, ldb, ldc, k int)
+//
+// Y0-Y7 hold the 4x16 block of c, 2 registers per row. Every step over k adds the products of a column of a with
+// a row of b, like matMulBlockFloat32Generic.
+TEXT ·matMulBlockFloat32AVX2(SB), NOSPLIT, $0-104
+	MOVQ   a_base+0(FP), SI
+	MOVQ   b_base+24(FP), DI
+	MOVQ   c_base+48(FP), DX
+	MOVQ   lda+72(FP), R8
+	MOVQ   ldb+80(FP), R10
+	MOVQ   ldc+88(FP), R11
+	MOVQ   k+96(FP), CX
+	SHLQ   $2, R8
+	SHLQ   $2, R10
+	SHLQ   $2, R11
+	LEAQ   (R8)(R8*2), R9
+	VXORPS Y0, Y0, Y0
+	VXORPS Y1, Y1, Y1
+	VXORPS Y2, Y2, Y2
+	VXORPS Y3, Y3, Y3
+	VXORPS Y4, Y4, Y4
+	VXORPS Y5, Y5, Y5
+	VXORPS Y6, Y6, Y6
+	VXORPS Y7, Y7, Y7
+	TESTQ  CX, CX
+	JZ     block32store
+
+block32loop:
+	VMOVUPS      (DI), Y8
+	VMOVUPS      32(DI), Y9
+
+	VBROADCASTSS (SI), Y10
+	VMULPS       Y8, Y10, Y11
+	VMULPS       Y9, Y10, Y12
+	VADDPS       Y11, Y0, Y0
+	VADDPS       Y12, Y1, Y1
+
+	VBROADCASTSS (SI)(R8*1), Y10
+	VMULPS       Y8, Y10, Y11
+	VMULPS       Y9, Y10, Y12
+	VADDPS       Y11, Y2, Y2
+	VADDPS       Y12, Y3, Y3
+
+	VBROADCASTSS (SI)(R8*2), Y10
+	VMULPS       Y8, Y10, Y11
+	VMULPS       Y9, Y10, Y12
+	VADDPS       Y11, Y4, Y4
+	VADDPS       Y12, Y5, Y5
+
+	VBROADCASTSS (SI)(R9*1), Y10
+	VMULPS       Y8, Y10, Y11
+	VMULPS       Y9, Y10, Y12
+	VADDPS       Y11, Y6, Y6
+	VADDPS       Y12, Y7, Y7
+
+	ADDQ         $4, SI
+	ADDQ         R10, DI
+	DECQ         CX
+	JNZ          block32loop
+
+block32store:
+	VMOVUPS    Y0, (DX)
+	VMOVUPS    Y1, 32(DX)
+	ADDQ       R11, DX
+	VMOVUPS    Y2, (DX)
+	VMOVUPS    Y3, 32(DX)
+	ADDQ       R11, DX
+	VMOVUPS    Y4, (DX)
+	VMOVUPS    Y5, 32(DX)
+	ADDQ       R11, DX
+	VMOVUPS    Y6, (DX)
+	VMOVUPS    Y7, 32(DX)
+	VZEROUPPER
+	RET
@@ -0,0 +1,163 @@
+package simd
+
+import (
+	"math"
+	"math/rand/v2"
+	"testing"
+)
+
+// Lengths covering empty slices, partial blocks & several full blocks plus a tail for every kernel.
+var testLengths = []int{0, 1, 3, 4, 7, 8, 15, 16, 17, 31, 33, 64, 100, 1023}
+
+func randomSlices[T float32 | float64](rng *rand.Rand, n int) (x, y []T) {
+	x, y = make([]T, n), make([]T, n)
+	for i := range x {
+		// mixed magnitudes, so that the order of the additions matters
+		x[i] = T((rng.Float64()*2 - 1) * math.Pow(10, float64(rng.IntN(8)-4)))
+		y[i] = T((rng.Float64()*2 - 1) * math.Pow(10, float64(rng.IntN(8)-4)))
+	}
+
+	return x, y
+}
+
+func sameBits[T float32 | float64](a, b T) bool {
+	return math.Float64bits(float64(a)) == math.Float64bits(float64(b))
+}
+
+func TestKernelsMatchGeneric(t *testing.T) {
+	if !Accelerated() {
+		t.Log("kernels are not accelerated on this machine, comparing the generic kernels with themselves")
+	}
+
+	rng := rand.New(rand.NewPCG(1, 2))
+	for _, n := range testLengths {
+		x64, y64 := randomSlices[float64](rng, n)
+		if got, want := DotFloat64(x64, y64), dotFloat64Generic(x64, y64); !sameBits(got, want) {
+			t.Errorf("DotFloat64(): length %d: got %v, want %v", n, got, want)
+		}
+
+		x32, y32 := randomSlices[float32](rng, n)
+		if got, want := DotFloat32(x32, y32), dotFloat32Generic(x32, y32); !sameBits(got, want) {
+			t.Errorf("DotFloat32(): length %d: got %v, want %v", n, got, want)
+		}
+
+		checkElementwise(t, "AxpyFloat64", n, x64, y64,
+			func(dst, x, y []float64) { copy(dst, y); AxpyFloat64(1.7, x, dst) },
+			func(dst, x, y []float64) { copy(dst, y); axpyFloat64Generic(1.7, x, dst) })
+		checkElementwise(t, "AxpyFloat32", n, x32, y32,
+			func(dst, x, y []float32) { copy(dst, y); AxpyFloat32(-0.3, x, dst) },
+			func(dst, x, y []float32) { copy(dst, y); axpyFloat32Generic(-0.3, x, dst) })
+		checkElementwise(t, "AddFloat64", n, x64, y64, AddFloat64, addFloat64Generic)
+		checkElementwise(t, "AddFloat32", n, x32, y32, AddFloat32, addFloat32Generic)
+		checkElementwise(t, "MulFloat64", n, x64, y64, MulFloat64, mulFloat64Generic)
+		checkElementwise(t, "MulFloat32", n, x32, y32, MulFloat32, mulFloat32Generic)
+	}
+}
+
+func TestMatMulMatchesNaive(t *testing.T) {
+	rng := rand.New(rand.NewPCG(3, 4))
+
+	// full blocks, partial blocks on either side & matrices smaller than a block
+	for _, dims := range [][3]int{{4, 3, 16}, {9, 17, 35}, {3, 5, 7}, {13, 1, 21}, {8, 0, 16}, {0, 4, 8}, {5, 4, 0}} {
+		m, k, n := dims[0], dims[1], dims[2]
+		checkMatMulKernel(t, rng, m, k, n, MatMulFloat64, matMulBlockFloat64Generic, 8)
+		checkMatMulKernel(t, rng, m, k, n, MatMulFloat32, matMulBlockFloat32Generic, 16)
+	}
+}
+
+// Checks that the kernel, the generic blocks & the naive algorithm all give the same bits.
+func checkMatMulKernel[T float32 | float64](t *testing.T, rng *rand.Rand, m, k, n int, kernel func(a, b, c []T, k, n int), block func(a, b, c []T, lda, ldb, ldc, k int), blockCols int) {
+	t.Helper()
+
+	a, _ := randomSlices[T](rng, m*k)
+	b, _ := randomSlices[T](rng, k*n)
+	got, generic, want := make([]T, m*n), make([]T, m*n), make([]T, m*n)
+	for i := range got {
+		got[i] = 1
+	}
+
+	kernel(a, b, got, k, n)
+	matMul(a, b, generic, k, n, blockCols, block)
+	matMulNaive(a, b, want, k, n, 0, m, 0, n)
+	for i := range want {
+		if !sameBits(got[i], want[i]) || !sameBits(generic[i], want[i]) {
+			t.Errorf("%dx%dx%d, index %d: got %v & %v, want %v", m, k, n, i, got[i], generic[i], want[i])
+			return
+		}
+	}
+}
+
+func checkElementwise[T float32 | float64](t *testing.T, name string, n int, x, y []T, kernel, generic func(dst, x, y []T)) {
+	t.Helper()
+
+	got, want := make([]T, n), make([]T, n)
+	kernel(got, x, y)
+	generic(want, x, y)
+	for i := range got {
+		if !sameBits(got[i], want[i]) {
+			t.Errorf("%s(): length %d, index %d: got %v, want %v", name, n, i, got[i], want[i])
+			return
+		}
+	}
+}
+
+func TestDot(t *testing.T) {
+	x := []float64{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	if result := DotFloat64(x, x); result != 385 {
+		t.Fatalf("expected 385, got %v", result)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for slices of different lengths")
+		}
+	}()
+
+	DotFloat64(x, x[1:])
+}
+
+// The size of the benchmarked matrices & vectors.
+const benchmarkSize = 256
+
+func BenchmarkDotFloat32(b *testing.B) {
+	x, y := randomSlices[float32](rand.New(rand.NewPCG(1, 2)), benchmarkSize*benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		DotFloat32(x, y)
+	}
+}
+
+func BenchmarkDotFloat32Generic(b *testing.B) {
+	x, y := randomSlices[float32](rand.New(rand.NewPCG(1, 2)), benchmarkSize*benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		dotFloat32Generic(x, y)
+	}
+}
+
+func BenchmarkMatMulFloat64(b *testing.B) {
+	benchmarkMatMul(b, matMulBlockFloat64, 8)
+}
+
+func BenchmarkMatMulFloat64Generic(b *testing.B) {
+	benchmarkMatMul(b, matMulBlockFloat64Generic, 8)
+}
+
+func BenchmarkMatMulFloat32(b *testing.B) {
+	benchmarkMatMul(b, matMulBlockFloat32, 16)
+}
+
+func BenchmarkMatMulFloat32Generic(b *testing.B) {
+	benchmarkMatMul(b, matMulBlockFloat32Generic, 16)
+}
+
+// Multiplies square matrices with the block kernel, like MatMulFloat64() & MatMulFloat32() do with the one chosen at
+// init.
+func benchmarkMatMul[T float32 | float64](b *testing.B, block func(a, b, c []T, lda, ldb, ldc, k int), blockCols int) {
+	x, y := randomSlices[T](rand.New(rand.NewPCG(1, 2)), benchmarkSize*benchmarkSize)
+	c := make([]T, benchmarkSize*benchmarkSize)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		matMul(x, y, c, benchmarkSize, benchmarkSize, blockCols, block)
+	}
+}
@@ -0,0 +1,56 @@
+package tensor
+
+import "github.com/biraj21/nnfs-go/tensor/internal/simd"
+
+// Multiplies the row-major float matrices a (m x k) & b (k x n) into result using the SIMD kernels. Returns false if T
+// is not float32 or float64, in which case result is left untouched.
+func matMulKernel[T Scalar](a, b, result []T, k, n int) bool {
+	switch resultData := any(result).(type) {
+	case []float64:
+		matMulRows(any(a).([]float64), any(b).([]float64), resultData, k, n, simd.MatMulFloat64)
+	case []float32:
+		matMulRows(any(a).([]float32), any(b).([]float32), resultData, k, n, simd.MatMulFloat32)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// Splits the rows of the result between goroutines, each of which multiplies its rows of a by b with the kernel.
+// Every element is still summed in the order of k, like the naive algorithm.
+func matMulRows[F float32 | float64](a, b, result []F, k, n int, matMul func(a, b, c []F, k, n int)) {
+	m := len(result) / max(n, 1)
+	parallelFor(m, minItemsPerChunk(k*n), func(start, end int) {
+		matMul(a[start*k:end*k], b, result[start*n:end*n], k, n)
+	})
+}
+
+// Computes dst = x op y using the SIMD kernels, if T is float32 or float64 & there is one for the operation. Returns
+// false otherwise, in which case dst is left untouched.
+func elementwiseKernel[T Scalar](op ElementwiseOp, dst, x, y []T) bool {
+	switch dstData := any(dst).(type) {
+	case []float64:
+		switch op {
+		case OpAdd:
+			simd.AddFloat64(dstData, any(x).([]float64), any(y).([]float64))
+		case OpMultiply:
+			simd.MulFloat64(dstData, any(x).([]float64), any(y).([]float64))
+		default:
+			return false
+		}
+	case []float32:
+		switch op {
+		case OpAdd:
+			simd.AddFloat32(dstData, any(x).([]float32), any(y).([]float32))
+		case OpMultiply:
+			simd.MulFloat32(dstData, any(x).([]float32), any(y).([]float32))
+		default:
+			return false
+		}
+	default:
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,39 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestMatMulKernel(t *testing.T) {
+	a := WithRandom[float64]([]uint{7, 19}, -10, 10)
+	b := WithRandom[float64]([]uint{19, 5}, -10, 10)
+
+	result := MatrixMultiplication(a, b)
+
+	// the kernel sums in the same order as the naive algorithm, so the results are bit-identical
+	for r := 0; r < 7; r++ {
+		for c := 0; c < 5; c++ {
+			var sum float64
+			for k := 0; k < 19; k++ {
+				sum += float64(a.Get(r, k) * b.Get(k, c))
+			}
+
+			if math.Float64bits(sum) != math.Float64bits(result.Get(r, c)) {
+				t.Fatalf("(%d, %d): expected %v, got %v", r, c, sum, result.Get(r, c))
+			}
+		}
+	}
+}
+
+func TestElementwiseKernel(t *testing.T) {
+	t1 := WithRandom[float32]([]uint{3, 11}, -1, 1)
+	t2 := WithRandom[float32]([]uint{3, 11}, -1, 1)
+
+	sum, product := Add(t1, t2), Multiply(t1, t2)
+	for i := range t1.data {
+		if sum.data[i] != t1.data[i]+t2.data[i] || product.data[i] != t1.data[i]*t2.data[i] {
+			t.Fatalf("index %d: unexpected sum %v or product %v of %v & %v", i, sum.data[i], product.data[i], t1.data[i], t2.data[i])
+		}
+	}
+}
@@ -0,0 +1,126 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Order in which the elements of a tensor are stored in memory.
+type Order int
+
+const (
+	// Row-major order, where the last index varies the fastest, like in C & NumPy. Tensors are created in this order
+	// unless requested otherwise.
+	OrderC Order = iota
+
+	// Column-major order, where the first index varies the fastest, like in Fortran, MATLAB & LAPACK.
+	OrderF
+)
+
+func (o Order) String() string {
+	switch o {
+	case OrderC:
+		return "C"
+	case OrderF:
+		return "F"
+	default:
+		return fmt.Sprintf("Order(%d)", int(o))
+	}
+}
+
+// Returns the strides of a column-major tensor of the given shape.
+func calculateFortranStrides(shape []uint) []uint {
+	strides := make([]uint, len(shape))
+
+	stride := uint(1)
+	for i, dim := range shape {
+		strides[i] = stride
+		stride *= dim
+	}
+
+	return strides
+}
+
+// Returns the strides of a tensor of the given shape stored in the given order.
+func stridesFor(shape []uint, order Order) []uint {
+	switch order {
+	case OrderC:
+		return calculateStrides(shape)
+	case OrderF:
+		return calculateFortranStrides(shape)
+	default:
+		panic(fmt.Sprintf("Invalid order %v", order))
+	}
+}
+
+// Creates a new tensor of the given shape from a copy of data, which holds its elements in the given order.
+//
+// For example, WithData([]uint{2, 3}, columns, tensor.OrderF) reads a 2x3 matrix stored column by column, like the
+// arrays of Fortran & MATLAB.
+func WithData[T Scalar](shape []uint, data []T, order Order) *Tensor[T] {
+	strides := stridesFor(shape, order)
+
+	dataCopy := allocData[T](len(data))
+	copy(dataCopy, data)
+
+	t := fromData(slices.Clone(shape), dataCopy)
+	t.strides = strides
+	return t
+}
+
+// Returns the number of elements to skip in the data to move by one along each dimension.
+func (t *Tensor[T]) Strides() []uint {
+	return t.strides
+}
+
+// Checks if the elements are stored contiguously in row-major (C) order. Tensors with at most one dimension of size
+// greater than 1 are both C & F contiguous.
+func (t *Tensor[T]) IsCContiguous() bool {
+	return hasStrides(t.shape, t.strides, calculateStrides(t.shape))
+}
+
+// Checks if the elements are stored contiguously in column-major (Fortran) order.
+func (t *Tensor[T]) IsFContiguous() bool {
+	return hasStrides(t.shape, t.strides, calculateFortranStrides(t.shape))
+}
+
+// Checks if the strides match the expected ones, ignoring dimensions of size 1 since their stride is never used.
+func hasStrides(shape, strides, expected []uint) bool {
+	for i, dim := range shape {
+		if dim != 1 && strides[i] != expected[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns a copy of the tensor stored in row-major (C) order.
+func (t *Tensor[T]) AsC() *Tensor[T] {
+	return gatherStrided(t.data, slices.Clone(t.shape), t.strides)
+}
+
+// Returns a copy of the tensor stored in column-major (Fortran) order, e.g. to pass its data to a library that expects
+// that order.
+func (t *Tensor[T]) AsFortran() *Tensor[T] {
+	// the column-major data of t is the row-major data of t with its dimensions reversed
+	reversedShape := slices.Clone(t.shape)
+	reversedStrides := slices.Clone(t.strides)
+	slices.Reverse(reversedShape)
+	slices.Reverse(reversedStrides)
+
+	result := gatherStrided(t.data, reversedShape, reversedStrides)
+	result.shape = slices.Clone(t.shape)
+	result.strides = calculateFortranStrides(t.shape)
+	return result
+}
+
+// Returns the tensor itself if it's stored in row-major order, otherwise a row-major copy. Operations that work on the
+// data directly, like the backend kernels, call it on their operands.
+func (t *Tensor[T]) rowMajor() *Tensor[T] {
+	if t.IsCContiguous() {
+		return t
+	}
+
+	return t.AsC()
+}
@@ -0,0 +1,112 @@
+package tensor
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestWithData(t *testing.T) {
+	// the same 2x3 matrix, stored row by row & column by column
+	c := WithData([]uint{2, 3}, []float64{1, 2, 3, 4, 5, 6}, OrderC)
+	f := WithData([]uint{2, 3}, []float64{1, 4, 2, 5, 3, 6}, OrderF)
+
+	expected := WithValue[float64]([][]float64{{1, 2, 3}, {4, 5, 6}})
+	if !reflect.DeepEqual(expected, c) {
+		t.Fatalf("expected %v, got %v", expected, c)
+	}
+
+	if !reflect.DeepEqual([]uint{1, 2}, f.Strides()) || f.Get(1, 0) != 4 || f.Get(0, 2) != 3 {
+		t.Fatalf("expected column-major strides [1 2], got %v", f.Strides())
+	}
+
+	if !Equal(expected, f) {
+		t.Fatalf("expected %v, got %v", expected, f)
+	}
+
+	if !c.IsCContiguous() || c.IsFContiguous() || f.IsCContiguous() || !f.IsFContiguous() {
+		t.Fatal("wrong contiguity flags for 2x3 matrices")
+	}
+
+	// vectors & tensors with a single non-trivial dimension are both
+	column := WithData([]uint{3, 1}, []float64{1, 2, 3}, OrderF)
+	if !column.IsCContiguous() || !column.IsFContiguous() {
+		t.Fatal("expected a column vector to be both C & F contiguous")
+	}
+}
+
+func TestAsFortran(t *testing.T) {
+	c := WithValue[int]([][][]int{{{1, 2}, {3, 4}, {5, 6}}, {{7, 8}, {9, 10}, {11, 12}}})
+
+	f := c.AsFortran()
+	expectedData := []int{1, 7, 3, 9, 5, 11, 2, 8, 4, 10, 6, 12}
+	if !reflect.DeepEqual(expectedData, f.data) || !reflect.DeepEqual([]uint{1, 2, 6}, f.strides) {
+		t.Fatalf("expected data %v & strides [1 2 6], got %v & %v", expectedData, f.data, f.strides)
+	}
+
+	if back := f.AsC(); !reflect.DeepEqual(c, back) {
+		t.Fatalf("AsC(): expected %v, got %v", c, back)
+	}
+
+	if f.String() != c.String() {
+		t.Fatalf("expected the same formatting, got %v & %v", f, c)
+	}
+}
+
+func TestFortranOperations(t *testing.T) {
+	a := WithValue[float64]([][]float64{{1, 2, 3}, {4, 5, 6}})
+	b := WithValue[float64]([][]float64{{1, -1}, {2, 0}, {0, 3}})
+	fa, fb := a.AsFortran(), b.AsFortran()
+
+	check := func(name string, expected, got *Tensor[float64]) {
+		t.Helper()
+		if !Equal(expected, got) {
+			t.Fatalf("%s: expected %v, got %v", name, expected, got)
+		}
+	}
+
+	check("MatrixMultiplication", MatrixMultiplication(a, b), MatrixMultiplication(fa, fb))
+	check("Add", Add(a, a), Add(fa, a))
+	check("Multiply", Multiply(a, Transpose(b)), Multiply(fa, Transpose(fb)))
+	check("Sum", Sum(a, 1), Sum(fa, 1))
+	check("Max", Max(a, 0), Max(fa, 0))
+	check("Reshape", a.Reshape(3, 2), fa.Reshape(3, 2))
+	check("Transpose", Transpose(a), Transpose(fa))
+	check("CumSum", CumSum(a, 1), CumSum(fa, 1))
+	check("Sort", Sort(b, 0), Sort(fb, 0))
+	check("Map", Map(a, func(v float64) float64 { return v * v }), Map(fa, func(v float64) float64 { return v * v }))
+	check("Cast", a, Cast[float64](Cast[float32](fa)))
+	check("Einsum", Einsum("ij,jk->ik", a, b), Einsum("ij,jk->ik", fa, fb))
+
+	input := WithRandom[float64]([]uint{1, 2, 4, 4}, -1, 1)
+	weight := WithRandom[float64]([]uint{3, 2, 2, 2}, -1, 1)
+	check("Conv", Conv(input, weight, ConvOptions{}), Conv(input.AsFortran(), weight.AsFortran(), ConvOptions{}))
+
+	// the data is serialized in row-major order
+	var expected, got bytes.Buffer
+	if err := SaveTxt(&expected, a); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SaveTxt(&got, fa); err != nil {
+		t.Fatal(err)
+	}
+
+	if expected.String() != got.String() {
+		t.Fatalf("SaveTxt(): expected %q, got %q", expected.String(), got.String())
+	}
+
+	encoded, err := fa.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	decoded := &Tensor[float64]{}
+	if err := decoded.UnmarshalBinary(encoded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(a, decoded) {
+		t.Fatalf("MarshalBinary(): expected %v, got %v", a, decoded)
+	}
+}
@@ -0,0 +1,168 @@
+package tensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// Magic bytes at the start of a binary encoded tensor.
+const binaryMagic = "NNFT"
+
+// Version of the binary encoding. Bump it whenever the format changes.
+const binaryVersion = 1
+
+// Data type bytes of the binary encoding for the half-precision types, which reflect reports as uint16. They're outside
+// the range of reflect.Kind values.
+const (
+	binaryKindFloat16  = 0x80
+	binaryKindBFloat16 = 0x81
+)
+
+// JSON representation of a tensor.
+type jsonTensor[T Scalar] struct {
+	DType string `json:"dtype"`
+	Shape []uint `json:"shape"`
+	Data  []T    `json:"data"`
+}
+
+// Implements json.Marshaler. The tensor is encoded as an object with its data type, shape & flattened data in row-major order.
+//
+// Note that NaN & infinite values can't be represented in JSON, so marshaling a tensor containing them fails.
+func (t *Tensor[T]) MarshalJSON() ([]byte, error) {
+	shape := t.shape
+	if shape == nil {
+		shape = []uint{}
+	}
+
+	return json.Marshal(jsonTensor[T]{
+		DType: t.dataType.String(),
+		Shape: shape,
+		Data:  t.rowMajor().data,
+	})
+}
+
+// Implements json.Unmarshaler. The encoded data type must be the same as T.
+func (t *Tensor[T]) UnmarshalJSON(b []byte) error {
+	var decoded jsonTensor[T]
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		return err
+	}
+
+	var zero T
+	dataType := reflect.TypeOf(zero)
+	if decoded.DType != dataType.String() {
+		return fmt.Errorf("%s expected data type %v, found %q", ErrorInvalidEncoding, dataType, decoded.DType)
+	}
+
+	return t.setFromDecoded(decoded.Shape, decoded.Data)
+}
+
+// Implements encoding.BinaryMarshaler.
+//
+// The format is the magic "NNFT", a version byte, the data type (see binaryKindOf()), the number of dimensions & each
+// dimension's size as uvarints, followed by the little-endian encoded data in row-major order.
+func (t *Tensor[T]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(binaryMagic)
+	buf.WriteByte(binaryVersion)
+	buf.WriteByte(binaryKindOf[T]())
+
+	buf.Write(binary.AppendUvarint(nil, uint64(len(t.shape))))
+	for _, dim := range t.shape {
+		buf.Write(binary.AppendUvarint(nil, uint64(dim)))
+	}
+
+	buf.Write(encodeElements(t.rowMajor().data))
+	return buf.Bytes(), nil
+}
+
+// Implements encoding.BinaryUnmarshaler. The encoded data type must be the same as T.
+func (t *Tensor[T]) UnmarshalBinary(b []byte) error {
+	if len(b) < len(binaryMagic)+2 || string(b[:len(binaryMagic)]) != binaryMagic {
+		return fmt.Errorf("%s missing %q header", ErrorInvalidEncoding, binaryMagic)
+	}
+
+	b = b[len(binaryMagic):]
+	if b[0] != binaryVersion {
+		return fmt.Errorf("%s unsupported version %d", ErrorInvalidEncoding, b[0])
+	}
+
+	if b[1] != binaryKindOf[T]() {
+		var zero T
+		return fmt.Errorf("%s expected data type %T, found kind %d", ErrorInvalidEncoding, zero, b[1])
+	}
+
+	b = b[2:]
+	numDims, n := binary.Uvarint(b)
+	if n <= 0 {
+		return fmt.Errorf("%s could not read the number of dimensions", ErrorInvalidEncoding)
+	}
+
+	b = b[n:]
+	if numDims > uint64(len(b)) {
+		return fmt.Errorf("%s %d dimensions are more than the remaining %d bytes", ErrorInvalidEncoding, numDims, len(b))
+	}
+
+	shape := make([]uint, numDims)
+	for i := range shape {
+		dim, n := binary.Uvarint(b)
+		if n <= 0 {
+			return fmt.Errorf("%s could not read the size of dimension %d", ErrorInvalidEncoding, i)
+		}
+
+		shape[i] = uint(dim)
+		b = b[n:]
+	}
+
+	data, err := decodeElements[T](b, dtypeOf[T]())
+	if err != nil {
+		return err
+	}
+
+	return t.setFromDecoded(shape, data)
+}
+
+// Returns the byte identifying T in the binary encoding, which is its reflect.Kind for the built-in types.
+func binaryKindOf[T Scalar]() byte {
+	var zero T
+	switch any(zero).(type) {
+	case Float16:
+		return binaryKindFloat16
+	case BFloat16:
+		return binaryKindBFloat16
+	default:
+		return byte(reflect.TypeOf(zero).Kind())
+	}
+}
+
+// Implements gob.GobEncoder using the binary encoding.
+func (t *Tensor[T]) GobEncode() ([]byte, error) {
+	return t.MarshalBinary()
+}
+
+// Implements gob.GobDecoder using the binary encoding.
+func (t *Tensor[T]) GobDecode(b []byte) error {
+	return t.UnmarshalBinary(b)
+}
+
+// Replaces the contents of the tensor with the decoded shape & data after validating them.
+func (t *Tensor[T]) setFromDecoded(shape []uint, data []T) error {
+	if shape == nil {
+		shape = []uint{}
+	}
+
+	numElements, err := checkedElementCount(shape, dtypeSizes[dtypeOf[T]()])
+	if err != nil {
+		return fmt.Errorf("%s %w", ErrorInvalidEncoding, err)
+	}
+
+	if len(data) != numElements {
+		return fmt.Errorf("%s expected %d elements for shape %v, found %d", ErrorInvalidEncoding, numElements, shape, len(data))
+	}
+
+	*t = *fromData(shape, data)
+	return nil
+}
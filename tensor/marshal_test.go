@@ -0,0 +1,94 @@
+package tensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMarshalJSON(t *testing.T) {
+	tensor := WithValue[float64]([][]float64{{1, 2.5}, {-3, 4}})
+
+	b, err := json.Marshal(tensor)
+	if err != nil {
+		t.Fatalf("json.Marshal(): unexpected error %v", err)
+	}
+
+	expectedJSON := `{"dtype":"float64","shape":[2,2],"data":[1,2.5,-3,4]}`
+	if string(b) != expectedJSON {
+		t.Fatalf("expected %s, got %s", expectedJSON, b)
+	}
+
+	var decoded Tensor[float64]
+	if err := json.Unmarshal(b, &decoded); err != nil {
+		t.Fatalf("json.Unmarshal(): unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(tensor, &decoded) {
+		t.Fatalf("expected %v, got %v", tensor, &decoded)
+	}
+
+	var wrongType Tensor[int]
+	if err := json.Unmarshal(b, &wrongType); err == nil {
+		t.Fatalf("json.Unmarshal(): expected an error for mismatched data type")
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	tensor := WithValue[int16]([][][]int16{{{1, -2}, {3, 4}}, {{5, 6}, {-7, 8}}})
+
+	b, err := tensor.MarshalBinary()
+	if err != nil {
+		t.Fatalf("MarshalBinary(): unexpected error %v", err)
+	}
+
+	var decoded Tensor[int16]
+	if err := decoded.UnmarshalBinary(b); err != nil {
+		t.Fatalf("UnmarshalBinary(): unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(tensor, &decoded) {
+		t.Fatalf("expected %v, got %v", tensor, &decoded)
+	}
+
+	var truncated Tensor[int16]
+	if err := truncated.UnmarshalBinary(b[:len(b)-1]); err == nil {
+		t.Fatalf("UnmarshalBinary(): expected an error for truncated data")
+	}
+
+	// 2^32 * 2^32 elements wrap around to 0 in 64 bits, which would match the missing data
+	crafted := append([]byte(binaryMagic), binaryVersion, binaryKindOf[int16](), 2)
+	crafted = binary.AppendUvarint(crafted, 1<<32)
+	crafted = binary.AppendUvarint(crafted, 1<<32)
+
+	var overflowing Tensor[int16]
+	if err := overflowing.UnmarshalBinary(crafted); err == nil {
+		t.Fatalf("UnmarshalBinary(): expected an error for a shape with too many elements, got shape %v", overflowing.Shape())
+	}
+}
+
+func TestGob(t *testing.T) {
+	type checkpoint struct {
+		Epoch   int
+		Weights *Tensor[float32]
+	}
+
+	saved := checkpoint{Epoch: 3, Weights: WithValue[float32]([][]float32{{0.25, -1}, {2, 8}})}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(saved); err != nil {
+		t.Fatalf("Encode(): unexpected error %v", err)
+	}
+
+	var loaded checkpoint
+	if err := gob.NewDecoder(&buf).Decode(&loaded); err != nil {
+		t.Fatalf("Decode(): unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(saved, loaded) {
+		t.Fatalf("expected %v, got %v", saved, loaded)
+	}
+}
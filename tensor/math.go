@@ -1,7 +1,16 @@
 package tensor
 
+import (
+	"fmt"
+	"slices"
+)
+
 // Performs matrix multiplication on two 2D matrices.
 func MatrixMultiplication[T Scalar](t1, t2 *Tensor[T]) (result *Tensor[T]) {
+	if isHalf[T]() {
+		return Cast[T](MatrixMultiplication(Cast[float32](t1), Cast[float32](t2)))
+	}
+
 	// check if both tensors are 2D matrices
 	if len(t1.shape) != 2 || len(t2.shape) != 2 {
 		panic("Both tensors must be 2D matrices!")
@@ -15,77 +24,104 @@ func MatrixMultiplication[T Scalar](t1, t2 *Tensor[T]) (result *Tensor[T]) {
 	resultShape := []uint{t1.shape[0], t2.shape[1]}
 	result = WithShape[T](resultShape)
 
-	for r := 0; r < int(resultShape[0]); r++ {
-		for c := 0; c < int(resultShape[1]); c++ {
-			sumOfProducts := T(0)
-			for k := 0; k < int(t1.shape[1]); k++ {
-				sumOfProducts += t1.Get(r, k) * t2.Get(k, c)
-			}
-
-			result.Set([]int{r, c}, sumOfProducts)
-		}
-	}
-
+	t1, t2 = t1.rowMajor(), t2.rowMajor()
+	GetBackend[T]().MatMul(t1.data, t2.data, result.data, int(t1.shape[0]), int(t1.shape[1]), int(t2.shape[1]))
 	return result
 }
 
 // Adds two tensors.
 func Add[T Scalar](t1, t2 *Tensor[T]) *Tensor[T] {
-	broadcasts := Broadcast(t1, t2)
-	b1 := broadcasts[0]
-	b2 := broadcasts[1]
-
-	result := WithShape[T](b1.shape)
-	numElements := int(countElementsFromShape(result.shape))
-	for i := 0; i < numElements; i++ {
-		result.data[i] = b1.FlattenedGet(i) + b2.FlattenedGet(i)
-	}
-
-	return result
+	return elementwise(OpAdd, t1, t2)
 }
 
 // Subtracts two tensors.
 func Subtract[T Scalar](t1, t2 *Tensor[T]) *Tensor[T] {
-	broadcasts := Broadcast(t1, t2)
-	b1 := broadcasts[0]
-	b2 := broadcasts[1]
-
-	result := WithShape[T](b1.shape)
-	numElements := int(countElementsFromShape(result.shape))
-	for i := 0; i < numElements; i++ {
-		result.data[i] = b1.FlattenedGet(i) - b2.FlattenedGet(i)
-	}
-
-	return result
+	return elementwise(OpSubtract, t1, t2)
 }
 
 // Multiplies two tensors.
 func Multiply[T Scalar](t1, t2 *Tensor[T]) *Tensor[T] {
-	broadcasts := Broadcast(t1, t2)
-	b1 := broadcasts[0]
-	b2 := broadcasts[1]
+	return elementwise(OpMultiply, t1, t2)
+}
 
-	result := WithShape[T](b1.shape)
-	numElements := int(countElementsFromShape(result.shape))
-	for i := 0; i < numElements; i++ {
-		result.data[i] = b1.FlattenedGet(i) * b2.FlattenedGet(i)
+// Divides two tensors.
+func Divide[T Scalar](t1, t2 *Tensor[T]) *Tensor[T] {
+	return elementwise(OpDivide, t1, t2)
+}
+
+// Broadcasts the two tensors together & computes the elementwise operation with the backend.
+func elementwise[T Scalar](op ElementwiseOp, t1, t2 *Tensor[T]) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](elementwise(op, Cast[float32](t1), Cast[float32](t2)))
+	}
+
+	broadcasts := Broadcast(t1, t2)
+	operands := make([][]T, len(broadcasts))
+	for i, b := range broadcasts {
+		// the backend works on plain row-major slices, so broadcast dimensions are expanded
+		if slices.Equal(b.shape, b.tensor.shape) {
+			operands[i] = b.tensor.rowMajor().data
+		} else {
+			operands[i] = b.ToTensor().data
+		}
 	}
 
+	result := WithShape[T](slices.Clone(broadcasts[0].shape))
+	GetBackend[T]().Elementwise(op, result.data, operands[0], operands[1])
 	return result
 }
 
-// Divides two tensors.
-func Divide[T Scalar](t1, t2 *Tensor[T]) *Tensor[T] {
-	broadcasts := Broadcast(t1, t2)
-	b1 := broadcasts[0]
-	b2 := broadcasts[1]
+// Returns the sum of the elements along the axis, which is removed from the shape. The sum of an empty axis is 0.
+// Negative axes count from the end.
+func Sum[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	return reduce(ReduceSum, t, axis)
+}
 
-	result := WithShape[T](b1.shape)
-	numElements := int(countElementsFromShape(result.shape))
-	for i := 0; i < numElements; i++ {
-		result.data[i] = b1.FlattenedGet(i) / b2.FlattenedGet(i)
+// Returns the product of the elements along the axis, which is removed from the shape. The product of an empty axis is
+// 1. Negative axes count from the end.
+func Prod[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	return reduce(ReduceProd, t, axis)
+}
+
+// Returns the largest elements along the axis, which is removed from the shape. NaNs propagate. Panics if the axis is
+// empty. Negative axes count from the end.
+func Max[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	return reduce(ReduceMax, t, axis)
+}
+
+// Returns the smallest elements along the axis, which is removed from the shape. NaNs propagate. Panics if the axis is
+// empty. Negative axes count from the end.
+func Min[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	return reduce(ReduceMin, t, axis)
+}
+
+// Computes the reduction along the axis with the backend.
+func reduce[T Scalar](op ReduceOp, t *Tensor[T], axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](reduce(op, Cast[float32](t), axis))
 	}
 
+	axis = normalizeAxis(axis, t.NDims())
+
+	outer := int(countElementsFromShape(t.shape[:axis]))
+	inner := int(countElementsFromShape(t.shape[axis+1:]))
+
+	shape := removeAxis(t.shape, axis)
+	if t.shape[axis] == 0 {
+		// reducing nothing gives the identity of the operation, like in NumPy
+		switch op {
+		case ReduceSum:
+			return WithShape[T](shape)
+		case ReduceProd:
+			return WithShape[T](shape, 1)
+		default:
+			panic(fmt.Sprintf("%v(): cannot reduce an empty axis, since the operation has no identity", op))
+		}
+	}
+
+	result := WithShape[T](shape)
+
+	GetBackend[T]().Reduce(op, result.data, t.rowMajor().data, outer, int(t.shape[axis]), inner)
 	return result
 }
 
@@ -121,7 +157,7 @@ func Transpose[T Scalar](t *Tensor[T]) *Tensor[T] {
 		}
 
 		// set the value at the reversed location to the transposed tensor
-		transposedTensor.Set(reversedLocation, t.Get(location...))
+		transposedTensor.UnsafeSet(reversedLocation, t.UnsafeGet(location...))
 	}
 
 	return transposedTensor
@@ -0,0 +1,292 @@
+package tensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// How the file backing a MappedTensor is mapped into memory.
+type MmapMode int
+
+const (
+	// The tensor can only be read. Writing to it crashes the program with a segmentation fault.
+	MmapReadOnly MmapMode = iota
+
+	// The tensor can be written to, but the changes are private to the process & never written back to the file.
+	MmapCopyOnWrite
+)
+
+// MappedTensor is a tensor whose data lives in a memory-mapped file instead of the Go heap. The OS reads pages of the
+// file only when they're accessed, so slicing mini-batches out of a dataset larger than RAM only reads what's needed.
+//
+// Close() must be called once the tensor is no longer needed. Tensors returned by Rows() are copies & stay valid after
+// that, but the one returned by Tensor() does not.
+type MappedTensor[T Scalar] struct {
+	tensor *Tensor[T]
+
+	// the whole mapping, which starts at a page boundary & so may begin before the data of the tensor
+	mapping []byte
+}
+
+// Maps a file holding the raw little-endian elements of a tensor of the given shape, starting at offset bytes into the
+// file. The elements must be stored as dtypeOf[T](), so for example int tensors are expected to be stored as int64.
+func OpenRaw[T Scalar](path string, shape []uint, offset int64, mode MmapMode) (*MappedTensor[T], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return mapTensor[T](f, slices.Clone(shape), OrderC, offset, mode)
+}
+
+// Maps a .npy file (https://numpy.org/doc/stable/reference/generated/numpy.lib.format.html). Its dtype must match T
+// exactly, for example '<f8' for float64 & '<i8' for int. Arrays stored in Fortran order are mapped as they are, so the
+// tensor is column-major too.
+func OpenNpy[T Scalar](path string, mode MmapMode) (*MappedTensor[T], error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	shape, order, offset, err := readNpyHeader[T](f)
+	if err != nil {
+		return nil, err
+	}
+
+	return mapTensor[T](f, shape, order, offset, mode)
+}
+
+func mapTensor[T Scalar](f *os.File, shape []uint, order Order, offset int64, mode MmapMode) (*MappedTensor[T], error) {
+	if mode != MmapReadOnly && mode != MmapCopyOnWrite {
+		return nil, fmt.Errorf("Invalid mmap mode %d", mode)
+	}
+
+	// the mapped bytes are used as the elements directly, so they must be laid out exactly like T in memory
+	var zero T
+	elementSize := int64(unsafe.Sizeof(zero))
+	if dtypeSizes[dtypeOf[T]()] != int(elementSize) || !isLittleEndian() {
+		return nil, fmt.Errorf("%s %T cannot be memory-mapped on this platform", ErrorUnsupportedDataType, zero)
+	}
+
+	if offset < 0 || offset%elementSize != 0 {
+		return nil, fmt.Errorf("Offset %d must be a non-negative multiple of the element size %d", offset, elementSize)
+	}
+
+	numElements := int64(countElementsFromShape(shape))
+	length := numElements * elementSize
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+
+	if offset+length > info.Size() {
+		return nil, fmt.Errorf("%s needs %d bytes at offset %d, but the file has %d bytes", ErrorShapeMismatch, length, offset, info.Size())
+	}
+
+	// there's nothing to map for an empty tensor, but it still needs a non-nil mapping so that it's open
+	mapping, data := []byte{}, []T{}
+	if length > 0 {
+		var start int
+		mapping, start, err = mmapFile(f, offset, length, mode)
+		if err != nil {
+			return nil, err
+		}
+
+		data = unsafe.Slice((*T)(unsafe.Pointer(&mapping[start])), numElements)
+
+		// the mapped memory must never end up in the pool of released buffers
+		registerUnpoolable(mapping)
+	}
+
+	t := fromData(shape, data)
+	t.strides = stridesFor(shape, order)
+	return &MappedTensor[T]{tensor: t, mapping: mapping}, nil
+}
+
+func isLittleEndian() bool {
+	x := uint16(1)
+	return *(*byte)(unsafe.Pointer(&x)) == 1
+}
+
+// Returns the tensor backed by the mapped file, without copying it. It must not be used after Close(). Note that the
+// results of most operations on it are regular tensors, so they stay valid.
+func (m *MappedTensor[T]) Tensor() *Tensor[T] {
+	if m.mapping == nil {
+		panic(ErrorMmapClosed)
+	}
+
+	return m.tensor
+}
+
+// Returns the shape of the tensor.
+func (m *MappedTensor[T]) Shape() []uint {
+	return m.tensor.shape
+}
+
+// Returns a copy of the rows start (inclusive) to end (exclusive) along the first axis, for example to get a
+// mini-batch. Only the pages holding those rows are read from the file.
+func (m *MappedTensor[T]) Rows(start, end int) *Tensor[T] {
+	if m.mapping == nil {
+		panic(ErrorMmapClosed)
+	}
+
+	t := m.tensor
+	if start < 0 || end > int(t.shape[0]) || start >= end {
+		panic(fmt.Sprintf("Rows(): invalid range [%d, %d) for shape %v", start, end, t.shape))
+	}
+
+	shape := slices.Clone(t.shape)
+	shape[0] = uint(end - start)
+
+	if !t.IsCContiguous() {
+		// the rows are spread over the whole file, e.g. in Fortran order
+		return gatherStrided(t.data[start*int(t.strides[0]):], shape, t.strides)
+	}
+
+	rowSize := len(t.data) / int(t.shape[0])
+	return fromData(shape, slices.Clone(t.data[start*rowSize:end*rowSize]))
+}
+
+// Unmaps the file. Using the tensor returned by Tensor() afterwards panics or crashes the program, so make sure that
+// nothing refers to it anymore.
+func (m *MappedTensor[T]) Close() error {
+	if m.mapping == nil {
+		return errors.New(ErrorMmapClosed)
+	}
+
+	var err error
+	if len(m.mapping) > 0 {
+		unregisterUnpoolable(m.mapping)
+		err = munmap(m.mapping)
+	}
+
+	m.mapping = nil
+
+	// so that accidental uses panic instead of reading unmapped memory
+	m.tensor.data = nil
+
+	return err
+}
+
+var (
+	npyMagic             = []byte("\x93NUMPY")
+	npyDescrPattern      = regexp.MustCompile(`'descr'\s*:\s*'([^']*)'`)
+	npyFortranPattern    = regexp.MustCompile(`'fortran_order'\s*:\s*(True|False)`)
+	npyShapePattern      = regexp.MustCompile(`'shape'\s*:\s*\(([^)]*)\)`)
+	npyKindsByTypeLetter = map[byte]byte{'F': 'f', 'I': 'i', 'U': 'u'}
+)
+
+// Reads the header of a .npy file & returns the shape & order of the array along with the offset at which its data
+// starts.
+func readNpyHeader[T Scalar](r io.Reader) (shape []uint, order Order, offset int64, err error) {
+	prefix := make([]byte, len(npyMagic)+2)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, 0, 0, fmt.Errorf("%s could not read magic string: %w", ErrorInvalidNpy, err)
+	}
+
+	if !bytes.Equal(prefix[:len(npyMagic)], npyMagic) {
+		return nil, 0, 0, fmt.Errorf("%s wrong magic string", ErrorInvalidNpy)
+	}
+
+	// version 1 stores the header length as a uint16, versions 2 & 3 as a uint32
+	var headerLength int64
+	switch major := prefix[len(npyMagic)]; major {
+	case 1:
+		var n uint16
+		err = binary.Read(r, binary.LittleEndian, &n)
+		headerLength = int64(n)
+		offset = int64(len(prefix)) + 2
+	case 2, 3:
+		var n uint32
+		err = binary.Read(r, binary.LittleEndian, &n)
+		headerLength = int64(n)
+		offset = int64(len(prefix)) + 4
+	default:
+		return nil, 0, 0, fmt.Errorf("%s unsupported version %d", ErrorInvalidNpy, major)
+	}
+
+	if err != nil {
+		return nil, 0, 0, fmt.Errorf("%s could not read header length: %w", ErrorInvalidNpy, err)
+	}
+
+	header := make([]byte, headerLength)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, 0, 0, fmt.Errorf("%s could not read header: %w", ErrorInvalidNpy, err)
+	}
+
+	offset += headerLength
+
+	descr := npyDescrPattern.FindSubmatch(header)
+	fortranOrder := npyFortranPattern.FindSubmatch(header)
+	shapeMatch := npyShapePattern.FindSubmatch(header)
+	if descr == nil || fortranOrder == nil || shapeMatch == nil {
+		return nil, 0, 0, fmt.Errorf("%s malformed header %q", ErrorInvalidNpy, header)
+	}
+
+	expected := npyDescrOf[T]()
+	if expected == "" {
+		var zero T
+		return nil, 0, 0, fmt.Errorf("%s %T has no .npy equivalent", ErrorUnsupportedDataType, zero)
+	}
+
+	if !npyDescrMatches(string(descr[1]), expected) {
+		return nil, 0, 0, fmt.Errorf("%s dtype %q does not match %q", ErrorUnsupportedDataType, descr[1], expected)
+	}
+
+	if string(fortranOrder[1]) == "True" {
+		order = OrderF
+	}
+
+	for _, dim := range strings.Split(string(shapeMatch[1]), ",") {
+		dim = strings.TrimSpace(dim)
+		if dim == "" {
+			continue
+		}
+
+		size, err := strconv.ParseUint(dim, 10, 64)
+		if err != nil {
+			return nil, 0, 0, fmt.Errorf("%s invalid shape %q", ErrorInvalidNpy, shapeMatch[1])
+		}
+
+		shape = append(shape, uint(size))
+	}
+
+	if _, err := checkedElementCount(shape, dtypeSizes[dtypeOf[T]()]); err != nil {
+		return nil, 0, 0, fmt.Errorf("%s %w", ErrorInvalidNpy, err)
+	}
+
+	return shape, order, offset, nil
+}
+
+// Returns the little-endian .npy dtype for T, e.g. "<f8" for float64, or "" if NumPy has none like for BFloat16.
+func npyDescrOf[T Scalar]() string {
+	dtype := dtypeOf[T]()
+	kind, ok := npyKindsByTypeLetter[dtype[0]]
+	if !ok {
+		return ""
+	}
+
+	return fmt.Sprintf("<%c%d", kind, dtypeSizes[dtype])
+}
+
+// Reports whether the .npy dtype descr is the little-endian dtype expected. Single-byte types have no byte order, so
+// NumPy writes them with '|' instead.
+func npyDescrMatches(descr, expected string) bool {
+	if descr == expected {
+		return true
+	}
+
+	return len(descr) == len(expected) && descr[1:] == expected[1:] && (descr[0] == '|' || (descr[0] == '=' && isLittleEndian()))
+}
@@ -0,0 +1,34 @@
+//go:build linux
+
+package tensor
+
+import (
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// Maps length bytes of the file starting at offset. mmap() requires the offset to be page-aligned, so the mapping may
+// start earlier than that & start is the index of the byte at offset in it.
+func mmapFile(f *os.File, offset, length int64, mode MmapMode) (mapping []byte, start int, err error) {
+	pageSize := int64(os.Getpagesize())
+	alignedOffset := offset - offset%pageSize
+
+	prot := syscall.PROT_READ
+	flags := syscall.MAP_SHARED
+	if mode == MmapCopyOnWrite {
+		prot |= syscall.PROT_WRITE
+		flags = syscall.MAP_PRIVATE
+	}
+
+	mapping, err = syscall.Mmap(int(f.Fd()), alignedOffset, int(length+offset-alignedOffset), prot, flags)
+	if err != nil {
+		return nil, 0, fmt.Errorf("mmap %s: %w", f.Name(), err)
+	}
+
+	return mapping, int(offset - alignedOffset), nil
+}
+
+func munmap(mapping []byte) error {
+	return syscall.Munmap(mapping)
+}
@@ -0,0 +1,156 @@
+//go:build linux
+
+package tensor
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// Writes a version 1 .npy file holding the float64 tensor & returns its path.
+func writeNpy(t *testing.T, tensor *Tensor[float64], descr string, fortranOrder string) string {
+	t.Helper()
+
+	dims := make([]string, len(tensor.shape))
+	for i, dim := range tensor.shape {
+		dims[i] = fmt.Sprint(dim)
+	}
+
+	header := fmt.Sprintf("{'descr': '%s', 'fortran_order': %s, 'shape': (%s,), }", descr, fortranOrder, strings.Join(dims, ", "))
+
+	// the header is padded with spaces & a newline so that the data is 64-byte aligned
+	padding := 64 - (10+len(header)+1)%64
+	header += strings.Repeat(" ", padding%64) + "\n"
+
+	content := append([]byte("\x93NUMPY\x01\x00"), byte(len(header)), byte(len(header)>>8))
+	content = append(content, header...)
+	content = append(content, encodeElements(tensor.data)...)
+
+	path := filepath.Join(t.TempDir(), "data.npy")
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestOpenNpy(t *testing.T) {
+	expected := WithValue[float64]([][]float64{{1, 2, 3}, {4, 5, 6}, {7, 8, 9}, {10, 11, 12}})
+	path := writeNpy(t, expected, "<f8", "False")
+
+	m, err := OpenNpy[float64](path, MmapReadOnly)
+	if err != nil {
+		t.Fatalf("OpenNpy(): unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(expected.shape, m.Shape()) || !reflect.DeepEqual(expected.data, m.Tensor().data) {
+		t.Fatalf("expected %v, got %v", expected, m.Tensor())
+	}
+
+	batch := m.Rows(1, 3)
+	if err := m.Close(); err != nil {
+		t.Fatalf("Close(): unexpected error %v", err)
+	}
+
+	// rows are copies, so they outlive the mapping
+	expectedBatch := WithValue[float64]([][]float64{{4, 5, 6}, {7, 8, 9}})
+	if !reflect.DeepEqual(expectedBatch, batch) {
+		t.Fatalf("Rows(): expected %v, got %v", expectedBatch, batch)
+	}
+
+	if err := m.Close(); err == nil {
+		t.Fatal("Close(): expected an error when closing twice")
+	}
+
+	if _, err := OpenNpy[float32](path, MmapReadOnly); err == nil {
+		t.Fatal("OpenNpy(): expected an error for a mismatching dtype")
+	}
+
+	// Fortran-ordered arrays are mapped as column-major tensors
+	m, err = OpenNpy[float64](writeNpy(t, expected.AsFortran(), "<f8", "True"), MmapReadOnly)
+	if err != nil {
+		t.Fatalf("OpenNpy(): unexpected error %v", err)
+	}
+	defer m.Close()
+
+	if !m.Tensor().IsFContiguous() || !Equal(expected, m.Tensor()) {
+		t.Fatalf("OpenNpy(): expected %v in Fortran order, got %v with strides %v", expected, m.Tensor(), m.Tensor().Strides())
+	}
+
+	if batch := m.Rows(1, 3); !reflect.DeepEqual(expectedBatch, batch) {
+		t.Fatalf("Rows(): expected %v, got %v", expectedBatch, batch)
+	}
+
+	// releasing the tensor detaches its data, which Close() must not depend on
+	released, err := OpenNpy[float64](path, MmapReadOnly)
+	if err != nil {
+		t.Fatalf("OpenNpy(): unexpected error %v", err)
+	}
+
+	released.Tensor().Release()
+	if err := released.Close(); err != nil {
+		t.Fatalf("Close(): unexpected error %v after Release()", err)
+	}
+}
+
+func TestOpenRaw(t *testing.T) {
+	data := []int32{-1, 1, 2, 3, 4, 5, 6}
+	path := filepath.Join(t.TempDir(), "data.bin")
+	if err := os.WriteFile(path, encodeElements(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	// skip the first element
+	m, err := OpenRaw[int32](path, []uint{3, 2}, 4, MmapCopyOnWrite)
+	if err != nil {
+		t.Fatalf("OpenRaw(): unexpected error %v", err)
+	}
+	defer m.Close()
+
+	expected := WithValue[int32]([][]int32{{1, 2}, {3, 4}, {5, 6}})
+	if !reflect.DeepEqual(expected.data, m.Tensor().data) {
+		t.Fatalf("expected %v, got %v", expected, m.Tensor())
+	}
+
+	// copy-on-write changes are not written back to the file
+	m.Tensor().Set([]int{0, 0}, 100)
+	if m.Tensor().Get(0, 0) != 100 {
+		t.Fatalf("expected the write to be visible, got %v", m.Tensor().Get(0, 0))
+	}
+
+	content, _ := os.ReadFile(path)
+	if !reflect.DeepEqual(encodeElements(data), content) {
+		t.Fatal("copy-on-write changes must not modify the file")
+	}
+
+	// neither the mapped memory nor any part of it can be pooled
+	if data := m.Tensor().data; !isUnpoolable(data) || !isUnpoolable(data[3:5]) || isUnpoolable(slices.Clone(data)) {
+		t.Fatal("expected the mapped memory, & only it, to be unpoolable")
+	}
+
+	if _, err := OpenRaw[int32](path, []uint{4, 2}, 0, MmapReadOnly); err == nil {
+		t.Fatal("OpenRaw(): expected an error for a file that is too small")
+	}
+}
+
+func TestOpenNpyOverflowingShape(t *testing.T) {
+	// 2^32 * 2^32 elements wrap around to 0 in 64 bits, which would need no data at all
+	header := "{'descr': '<f8', 'fortran_order': False, 'shape': (4294967296, 4294967296), }\n"
+	content := append([]byte("\x93NUMPY\x01\x00"), byte(len(header)), byte(len(header)>>8))
+	content = append(content, header...)
+
+	path := filepath.Join(t.TempDir(), "data.npy")
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if m, err := OpenNpy[float64](path, MmapReadOnly); err == nil {
+		m.Close()
+		t.Fatal("OpenNpy(): expected an error for a shape with too many elements")
+	}
+}
@@ -0,0 +1,16 @@
+//go:build !linux
+
+package tensor
+
+import (
+	"errors"
+	"os"
+)
+
+func mmapFile(f *os.File, offset, length int64, mode MmapMode) (mapping []byte, start int, err error) {
+	return nil, 0, errors.New(ErrorMmapUnsupported)
+}
+
+func munmap(mapping []byte) error {
+	return errors.New(ErrorMmapUnsupported)
+}
@@ -0,0 +1,197 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Returns a mask with 1 where the elements of the tensor are NaN, and 0 elsewhere.
+func IsNaN[T Scalar](t *Tensor[T]) *Tensor[uint8] {
+	return mask(t, math.IsNaN)
+}
+
+// Returns a mask with 1 where the elements of the tensor are positive or negative infinity, and 0 elsewhere.
+func IsInf[T Scalar](t *Tensor[T]) *Tensor[uint8] {
+	return mask(t, func(value float64) bool { return math.IsInf(value, 0) })
+}
+
+// Returns a mask with 1 where the elements of the tensor are neither NaN nor infinite, and 0 elsewhere. Integer tensors
+// are always finite.
+func IsFinite[T Scalar](t *Tensor[T]) *Tensor[uint8] {
+	return mask(t, func(value float64) bool { return !math.IsNaN(value) && !math.IsInf(value, 0) })
+}
+
+// Returns a mask of the same shape & layout as the tensor, with 1 where f returns true for the element.
+func mask[T Scalar](t *Tensor[T], f func(value float64) bool) *Tensor[uint8] {
+	data := allocData[uint8](len(t.data))
+	for i, value := range t.data {
+		if f(toFloat64(value)) {
+			data[i] = 1
+		}
+	}
+
+	result := fromData(slices.Clone(t.shape), data)
+	result.strides = slices.Clone(t.strides)
+	return result
+}
+
+// Returns a copy of the tensor with NaNs replaced by nan, positive infinities by posInf & negative ones by negInf.
+//
+// For example, NanToNum(t, 0, math.MaxFloat64, -math.MaxFloat64) does the same as numpy.nan_to_num() for float64.
+func NanToNum[T Scalar](t *Tensor[T], nan, posInf, negInf T) *Tensor[T] {
+	result := t.Copy()
+	for i, value := range result.data {
+		switch f := toFloat64(value); {
+		case math.IsNaN(f):
+			result.data[i] = nan
+		case math.IsInf(f, 1):
+			result.data[i] = posInf
+		case math.IsInf(f, -1):
+			result.data[i] = negInf
+		}
+	}
+
+	return result
+}
+
+// Returns the sum of the elements along the axis ignoring NaNs, which is removed from the shape. Lanes of NaNs sum to
+// 0. Negative axes count from the end.
+func NanSum[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](NanSum(Cast[float32](t), axis))
+	}
+
+	return reduceLanes(t, axis, func(lane []T) T {
+		sum, _ := nanSum(lane)
+		return sum
+	})
+}
+
+// Returns the mean of the elements along the axis ignoring NaNs, which is removed from the shape. The mean of a lane of
+// NaNs is NaN. Only float tensors are supported, since integers can't hold fractional means nor NaN. Negative axes count
+// from the end.
+func NanMean[T FloatScalar | HalfScalar](t *Tensor[T], axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](NanMean(Cast[float32](t), axis))
+	}
+
+	return reduceLanes(t, axis, func(lane []T) T {
+		sum, count := nanSum(lane)
+		if count == 0 {
+			return T(math.NaN())
+		}
+
+		return sum / T(count)
+	})
+}
+
+// Returns the standard deviation of the elements along the axis ignoring NaNs, which is removed from the shape. Like
+// numpy.nanstd(), it's the population standard deviation, i.e. the mean squared deviation is divided by the number of
+// values. It's NaN for a lane of NaNs. Only float tensors are supported, like for NanMean(). Negative axes count from
+// the end.
+func NanStd[T FloatScalar | HalfScalar](t *Tensor[T], axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](NanStd(Cast[float32](t), axis))
+	}
+
+	return reduceLanes(t, axis, func(lane []T) T {
+		sum, count := nanSum(lane)
+		if count == 0 {
+			return T(math.NaN())
+		}
+
+		mean := float64(sum) / float64(count)
+		squares := 0.0
+		for _, value := range lane {
+			if !isNaN(value) {
+				deviation := float64(value) - mean
+				squares += deviation * deviation
+			}
+		}
+
+		return T(math.Sqrt(squares / float64(count)))
+	})
+}
+
+// Returns the largest elements along the axis ignoring NaNs, which is removed from the shape. The maximum of a lane of
+// NaNs is NaN, so only float tensors are supported; Max() does the same for integers. Panics if the axis is empty.
+// Negative axes count from the end.
+func NanMax[T FloatScalar | HalfScalar](t *Tensor[T], axis int) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](NanMax(Cast[float32](t), axis))
+	}
+
+	ensureNonEmptyAxis("NanMax", t, axis)
+	return reduceLanes(t, axis, func(lane []T) T {
+		if i := nanArgMax(lane); i >= 0 {
+			return lane[i]
+		}
+
+		return T(math.NaN())
+	})
+}
+
+// Returns the indices of the largest elements along the axis ignoring NaNs, which is removed from the shape. The first
+// index is returned for ties. Panics if the axis is empty or a lane has only NaNs, since there is no valid index to
+// return. Negative axes count from the end.
+func NanArgMax[T Scalar](t *Tensor[T], axis int) *Tensor[int] {
+	ensureNonEmptyAxis("NanArgMax", t, axis)
+	return reduceLanes(t, axis, func(lane []T) int {
+		i := nanArgMax(lane)
+		if i < 0 {
+			panic(fmt.Sprintf("NanArgMax(): found a lane of NaNs along axis %d of a tensor of shape %v", axis, t.shape))
+		}
+
+		return i
+	})
+}
+
+// Returns the sum of the values that aren't NaN & their number.
+func nanSum[T Scalar](values []T) (sum T, count int) {
+	for _, value := range values {
+		if !isNaN(value) {
+			sum += value
+			count++
+		}
+	}
+
+	return sum, count
+}
+
+// Returns the index of the first largest value that isn't NaN, or -1 if they are all NaNs.
+func nanArgMax[T Scalar](values []T) int {
+	best := -1
+	for i, value := range values {
+		if !isNaN(value) && (best < 0 || compareScalars(value, values[best]) > 0) {
+			best = i
+		}
+	}
+
+	return best
+}
+
+func ensureNonEmptyAxis[T Scalar](function string, t *Tensor[T], axis int) {
+	if t.shape[normalizeAxis(axis, t.NDims())] == 0 {
+		panic(fmt.Sprintf("%s(): cannot reduce an empty axis, since the operation has no identity", function))
+	}
+}
+
+// Reduces every 1D lane of the tensor along the axis to a single value with f. The axis is removed from the shape of
+// the result. The lane slice passed to f is reused between calls.
+func reduceLanes[T Scalar, R Scalar](t *Tensor[T], axis int, f func(lane []T) R) *Tensor[R] {
+	axis = normalizeAxis(axis, t.NDims())
+
+	result := WithShape[R](removeAxis(t.shape, axis))
+	stride := int(t.strides[axis])
+	lane := make([]T, t.shape[axis])
+	for i, start := range laneStarts(t.shape, t.strides, axis) {
+		for j := range lane {
+			lane[j] = t.data[start+j*stride]
+		}
+
+		result.data[i] = f(lane)
+	}
+
+	return result
+}
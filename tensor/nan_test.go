@@ -0,0 +1,91 @@
+package tensor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestIsNaNIsInfIsFinite(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	tensor := WithValue[float64]([][]float64{{1, nan, -inf}, {inf, 0, nan}})
+
+	tests := []struct {
+		name     string
+		mask     *Tensor[uint8]
+		expected [][]uint8
+	}{
+		{"IsNaN", IsNaN(tensor), [][]uint8{{0, 1, 0}, {0, 0, 1}}},
+		{"IsInf", IsInf(tensor), [][]uint8{{0, 0, 1}, {1, 0, 0}}},
+		{"IsFinite", IsFinite(tensor), [][]uint8{{1, 0, 0}, {0, 1, 0}}},
+		{"IsNaN of a column-major tensor", IsNaN(tensor.AsFortran()), [][]uint8{{0, 1, 0}, {0, 0, 1}}},
+		{"IsNaN of a Float16 tensor", IsNaN(Cast[Float16](tensor)), [][]uint8{{0, 1, 0}, {0, 0, 1}}},
+	}
+
+	for _, test := range tests {
+		if expected := WithValue[uint8](test.expected); !Equal(expected, test.mask) {
+			t.Fatalf("%s: expected %v, got %v", test.name, expected, test.mask)
+		}
+	}
+
+	if mask := IsFinite(WithValue[int]([]int{1, 2})); !reflect.DeepEqual(WithValue[uint8]([]uint8{1, 1}), mask) {
+		t.Fatalf("IsFinite(): expected integers to be finite, got %v", mask)
+	}
+}
+
+func TestNanToNum(t *testing.T) {
+	tensor := WithValue[float32]([]float32{1, float32(math.NaN()), float32(math.Inf(1)), float32(math.Inf(-1))})
+
+	result := NanToNum(tensor, 0, math.MaxFloat32, -math.MaxFloat32)
+	expected := WithValue[float32]([]float32{1, 0, math.MaxFloat32, -math.MaxFloat32})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	if !isNaN(tensor.Get(1)) {
+		t.Fatal("NanToNum() modified its input")
+	}
+}
+
+func TestNanReductions(t *testing.T) {
+	nan := math.NaN()
+	tensor := WithValue[float64]([][]float64{
+		{1, nan, 3},
+		{nan, nan, nan},
+		{4, 2, 6},
+	})
+
+	check := func(name string, expected []float64, got *Tensor[float64]) {
+		t.Helper()
+		if !AllClose(WithValue[float64](expected), got, Tolerance{RTol: 1e-12, EqualNaN: true}) {
+			t.Fatalf("%s: expected %v, got %v", name, expected, got)
+		}
+	}
+
+	check("NanSum", []float64{4, 0, 12}, NanSum(tensor, 1))
+	check("NanSum along the columns", []float64{5, 2, 9}, NanSum(tensor, 0))
+	check("NanMean", []float64{2, nan, 4}, NanMean(tensor, -1))
+	check("NanMax", []float64{3, nan, 6}, NanMax(tensor, 1))
+	check("NanStd", []float64{1, nan, math.Sqrt(8.0 / 3)}, NanStd(tensor, 1))
+
+	if indices := NanArgMax(tensor, 0); !reflect.DeepEqual(WithValue[int]([]int{2, 2, 2}), indices) {
+		t.Fatalf("NanArgMax(): expected [2 2 2], got %v", indices)
+	}
+
+	half := NanMean(Cast[BFloat16](tensor), 0)
+	if !AllClose(WithValue[float32]([]float32{2.5, 2, 4.5}), Cast[float32](half)) {
+		t.Fatalf("NanMean(): expected [2.5 2 4.5] for BFloat16, got %v", half)
+	}
+
+	if maximum := Cast[float32](NanMax(Cast[Float16](tensor), 1)); !AllClose(WithValue[float32]([]float32{3, float32(nan), 6}), maximum, Tolerance{EqualNaN: true}) {
+		t.Fatalf("NanMax(): expected [3 NaN 6] for Float16, got %v", maximum)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("NanArgMax(): expected a panic for a lane of NaNs")
+		}
+	}()
+
+	NanArgMax(tensor, 1)
+}
@@ -0,0 +1,144 @@
+package tensor
+
+import "fmt"
+
+// How Pad() fills the added elements.
+type PadMode int
+
+const (
+	// Pads with a constant value, zero by default. For example, [1 2 3] padded by 2 gives [0 0 1 2 3 0 0].
+	PadConstant PadMode = iota
+
+	// Pads with the edge values. For example, [1 2 3] padded by 2 gives [1 1 1 2 3 3 3].
+	PadEdge
+
+	// Pads with the reflection of the values, without repeating the edge. For example, [1 2 3] padded by 2 gives
+	// [3 2 1 2 3 2 1].
+	PadReflect
+
+	// Pads with the reflection of the values, repeating the edge. For example, [1 2 3] padded by 2 gives
+	// [2 1 1 2 3 3 2].
+	PadSymmetric
+
+	// Pads with the values from the opposite end, as if the tensor was repeated. For example, [1 2 3] padded by 2 gives
+	// [2 3 1 2 3 1 2].
+	PadWrap
+)
+
+// Returns a new tensor with widths[i][0] elements added before & widths[i][1] elements added after the elements of
+// axis i, filled according to the mode. If widths has a single entry, it's used for every axis. The constant value is
+// only used with PadConstant, which is also the only mode that can pad an axis of size 0.
+func Pad[T Scalar](t *Tensor[T], widths [][2]uint, mode PadMode, constantValue ...T) *Tensor[T] {
+	if len(constantValue) > 1 {
+		panic("Only one constant value is allowed!")
+	}
+
+	if len(widths) == 1 {
+		width := widths[0]
+		widths = make([][2]uint, t.NDims())
+		for i := range widths {
+			widths[i] = width
+		}
+	}
+
+	if len(widths) != t.NDims() {
+		panic(fmt.Sprintf("Pad(): expected 1 or %d pad widths for a tensor of shape %v, got %d", t.NDims(), t.shape, len(widths)))
+	}
+
+	var constant T
+	if len(constantValue) > 0 {
+		constant = constantValue[0]
+	}
+
+	// for every axis, map each index of the result to the index of the source element or -1 for the constant
+	shape := make([]uint, t.NDims())
+	sourceIndices := make([][]int, t.NDims())
+	for axis, width := range widths {
+		size := int(t.shape[axis])
+		if size == 0 && mode != PadConstant && width != [2]uint{} {
+			panic(fmt.Sprintf("Pad(): only PadConstant can pad axis %d of size 0 of a tensor of shape %v", axis, t.shape))
+		}
+
+		shape[axis] = t.shape[axis] + width[0] + width[1]
+		sourceIndices[axis] = make([]int, shape[axis])
+		for i := range sourceIndices[axis] {
+			sourceIndices[axis][i] = padSourceIndex(i-int(width[0]), size, mode)
+		}
+	}
+
+	result := WithShape[T](shape)
+	indices := make([]int, len(shape))
+	for i := range result.data {
+		dataIndex := 0
+		for axis, index := range indices {
+			source := sourceIndices[axis][index]
+			if source < 0 {
+				dataIndex = -1
+				break
+			}
+
+			dataIndex += source * int(t.strides[axis])
+		}
+
+		if dataIndex < 0 {
+			result.data[i] = constant
+		} else {
+			result.data[i] = t.data[dataIndex]
+		}
+
+		// move on to the next indices in row-major order
+		for dim := len(shape) - 1; dim >= 0; dim-- {
+			indices[dim]++
+			if indices[dim] < int(shape[dim]) {
+				break
+			}
+
+			indices[dim] = 0
+		}
+	}
+
+	return result
+}
+
+// Maps index i (which may be outside [0, size)) of an axis to the index of the element it's padded with, or -1 for
+// the constant.
+func padSourceIndex(i, size int, mode PadMode) int {
+	if i >= 0 && i < size {
+		return i
+	}
+
+	// Go's % can be negative, so this is the mathematical modulo
+	mod := func(a, b int) int {
+		return ((a % b) + b) % b
+	}
+
+	switch mode {
+	case PadConstant:
+		return -1
+	case PadEdge:
+		return min(max(i, 0), size-1)
+	case PadReflect:
+		if size == 1 {
+			return 0
+		}
+
+		period := 2 * (size - 1)
+		i = mod(i, period)
+		if i >= size {
+			i = period - i
+		}
+
+		return i
+	case PadSymmetric:
+		i = mod(i, 2*size)
+		if i >= size {
+			i = 2*size - 1 - i
+		}
+
+		return i
+	case PadWrap:
+		return mod(i, size)
+	default:
+		panic(fmt.Sprintf("Pad(): unknown pad mode %d", mode))
+	}
+}
@@ -0,0 +1,74 @@
+package tensor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestPadModes(t *testing.T) {
+	tensor := WithValue[int]([]int{1, 2, 3})
+	widths := [][2]uint{{2, 2}}
+
+	tests := []struct {
+		mode     PadMode
+		expected []int
+	}{
+		{PadConstant, []int{0, 0, 1, 2, 3, 0, 0}},
+		{PadEdge, []int{1, 1, 1, 2, 3, 3, 3}},
+		{PadReflect, []int{3, 2, 1, 2, 3, 2, 1}},
+		{PadSymmetric, []int{2, 1, 1, 2, 3, 3, 2}},
+		{PadWrap, []int{2, 3, 1, 2, 3, 1, 2}},
+	}
+
+	for _, test := range tests {
+		expected := WithValue[int](test.expected)
+		result := Pad(tensor, widths, test.mode)
+		if !reflect.DeepEqual(expected, result) {
+			t.Fatalf("mode %d: expected %v, got %v", test.mode, expected, result)
+		}
+	}
+}
+
+func TestPad2D(t *testing.T) {
+	tensor := WithValue[int]([][]int{{1, 2}, {3, 4}})
+
+	expected := WithValue[int]([][]int{
+		{-1, -1, -1},
+		{1, 2, -1},
+		{3, 4, -1},
+	})
+	result := Pad(tensor, [][2]uint{{1, 0}, {0, 1}}, PadConstant, -1)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	// wider than the tensor itself
+	expected = WithValue[int]([][]int{{1, 2, 1, 2, 1, 2, 1}, {3, 4, 3, 4, 3, 4, 3}})
+	result = Pad(tensor, [][2]uint{{0, 0}, {2, 3}}, PadReflect)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	// only the constant can pad an empty axis
+	empty := WithShape[int]([]uint{2, 0})
+	if result := Pad(empty, [][2]uint{{0, 0}, {1, 1}}, PadConstant, 7); !reflect.DeepEqual(WithValue[int]([][]int{{7, 7}, {7, 7}}), result) {
+		t.Fatalf("expected [[7 7] [7 7]], got %v", result)
+	}
+
+	if result := Pad(empty, [][2]uint{{1, 0}, {0, 0}}, PadWrap); !reflect.DeepEqual([]uint{3, 0}, result.Shape()) {
+		t.Fatalf("expected shape [3 0] when the empty axis isn't padded, got %v", result.Shape())
+	}
+
+	for _, mode := range []PadMode{PadEdge, PadReflect, PadSymmetric, PadWrap} {
+		func() {
+			defer func() {
+				if message, _ := recover().(string); !strings.Contains(message, "size 0") {
+					t.Fatalf("mode %d: expected a panic for an empty axis, got %q", mode, message)
+				}
+			}()
+
+			Pad(empty, [][2]uint{{0, 0}, {1, 1}}, mode)
+		}()
+	}
+}
@@ -0,0 +1,134 @@
+package tensor
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// Default minimum amount of work, roughly in elements or multiply-adds, that's worth handing to another goroutine.
+const defaultMinParallelWork = 1 << 15
+
+var (
+	// maximum number of goroutines per operation, or 0 for GOMAXPROCS
+	numThreads atomic.Int64
+
+	minParallelWork atomic.Int64
+
+	// number of goroutines started by parallelFor() that are still running
+	activeWorkers atomic.Int64
+)
+
+func init() {
+	minParallelWork.Store(defaultMinParallelWork)
+}
+
+// Sets the maximum number of goroutines that operations (elementwise ops, reductions, matrix multiplication,
+// convolution, ...) run on, and returns the previous value. n <= 0 means runtime.GOMAXPROCS(0), which is the default.
+//
+// The cap is shared by all the operations, including nested ones like the matrix multiplications of a convolution:
+// together they start at most n - 1 goroutines, and do the rest of the work on the goroutines that call them.
+//
+// Results don't depend on the number of threads: work is only split where every part is computed exactly the same way
+// as it would be sequentially. The setting is global, but it can be changed around a single call, e.g.
+//
+//	defer tensor.SetNumThreads(tensor.SetNumThreads(1))
+func SetNumThreads(n int) (previous int) {
+	return int(numThreads.Swap(int64(max(n, 0))))
+}
+
+// Returns the maximum number of goroutines that operations run on.
+func GetNumThreads() int {
+	if n := int(numThreads.Load()); n > 0 {
+		return n
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+// Sets the minimum amount of work, roughly in elements or multiply-adds, for which an operation uses another goroutine,
+// and returns the previous value. Smaller operations run on the calling goroutine, since starting goroutines would cost
+// more than it saves. n <= 0 restores the default.
+func SetParallelThreshold(n int) (previous int) {
+	if n <= 0 {
+		n = defaultMinParallelWork
+	}
+
+	return int(minParallelWork.Swap(int64(n)))
+}
+
+// Returns the minimum number of items per goroutine for parallelFor() when every item takes workPerItem.
+func minItemsPerChunk(workPerItem int) int {
+	threshold := int(minParallelWork.Load())
+	return max(1, (threshold+workPerItem-1)/max(workPerItem, 1))
+}
+
+// Calls f for consecutive chunks [start, end) covering [0, n), in parallel when there is enough work. Chunks have at
+// least minChunkSize items, so small loops run on the calling goroutine. The calling goroutine runs the chunks for
+// which no worker is available, so nested calls never start more than GetNumThreads() - 1 workers in total.
+//
+// A panic in f is re-raised on the calling goroutine once all the chunks are done, so it can be recovered like for a
+// sequential loop.
+func parallelFor(n, minChunkSize int, f func(start, end int)) {
+	numChunks := min(GetNumThreads(), n/max(minChunkSize, 1))
+	if numChunks <= 1 {
+		f(0, n)
+		return
+	}
+
+	chunkSize := (n + numChunks - 1) / numChunks
+
+	var (
+		wg         sync.WaitGroup
+		panicOnce  sync.Once
+		panicValue any
+		panicked   bool
+	)
+
+	run := func(start, end int) {
+		defer func() {
+			if r := recover(); r != nil {
+				panicOnce.Do(func() { panicValue, panicked = r, true })
+			}
+		}()
+
+		f(start, end)
+	}
+
+	for start := chunkSize; start < n; start += chunkSize {
+		end := min(start+chunkSize, n)
+		if !acquireWorker() {
+			run(start, end)
+			continue
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer activeWorkers.Add(-1)
+			run(start, end)
+		}()
+	}
+
+	run(0, chunkSize)
+	wg.Wait()
+
+	if panicked {
+		panic(panicValue)
+	}
+}
+
+// Reserves one of the GetNumThreads() - 1 workers that all the operations share, if any is available.
+func acquireWorker() bool {
+	limit := int64(GetNumThreads() - 1)
+	for {
+		n := activeWorkers.Load()
+		if n >= limit {
+			return false
+		}
+
+		if activeWorkers.CompareAndSwap(n, n+1) {
+			return true
+		}
+	}
+}
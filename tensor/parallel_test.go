@@ -0,0 +1,105 @@
+package tensor
+
+import (
+	"reflect"
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestSetNumThreads(t *testing.T) {
+	defer SetNumThreads(SetNumThreads(3))
+
+	if n := GetNumThreads(); n != 3 {
+		t.Fatalf("expected 3 threads, got %d", n)
+	}
+
+	if previous := SetNumThreads(0); previous != 3 {
+		t.Fatalf("expected the previous value 3, got %d", previous)
+	}
+
+	if n := GetNumThreads(); n != runtime.GOMAXPROCS(0) {
+		t.Fatalf("expected GOMAXPROCS threads by default, got %d", n)
+	}
+}
+
+func TestParallelDeterminism(t *testing.T) {
+	// split even the smallest operations across goroutines
+	defer SetParallelThreshold(SetParallelThreshold(1))
+	defer SetNumThreads(SetNumThreads(1))
+
+	a := WithRandom[float64]([]uint{37, 53}, -1, 1)
+	b := WithRandom[float64]([]uint{53, 29}, -1, 1)
+	c := WithRandom[float64]([]uint{37, 53}, -1, 1)
+	input := WithRandom[float64]([]uint{3, 4, 11, 13}, -1, 1)
+	weight := WithRandom[float64]([]uint{6, 2, 3, 3}, -1, 1)
+	ints := WithRandom[int64]([]uint{19, 23}, -100, 100)
+
+	compute := func() []any {
+		return []any{
+			MatrixMultiplication(a, b),
+			MatrixMultiplication(ints, Transpose(ints)),
+			Add(a, c),
+			Subtract(a, c),
+			Multiply(a, c),
+			Divide(a, c),
+			Sum(a, 0),
+			Sum(a, 1),
+			Prod(c, 0),
+			Max(ints, 1),
+			Conv(input, weight, ConvOptions{Padding: []int{1, 1}, Groups: 2}),
+		}
+	}
+
+	expected := compute()
+	for _, numThreads := range []int{2, 3, 8, 64} {
+		SetNumThreads(numThreads)
+		if results := compute(); !reflect.DeepEqual(expected, results) {
+			t.Fatalf("results with %d threads differ from the sequential ones", numThreads)
+		}
+	}
+}
+
+func TestParallelForPanic(t *testing.T) {
+	defer SetNumThreads(SetNumThreads(4))
+
+	defer func() {
+		if r := recover(); r != "chunk 3" {
+			t.Fatalf("expected the panic of the worker to be re-raised on the caller, got %v", r)
+		}
+	}()
+
+	parallelFor(8, 2, func(start, end int) {
+		if start == 6 {
+			panic("chunk 3")
+		}
+	})
+}
+
+func TestParallelForNested(t *testing.T) {
+	defer SetNumThreads(SetNumThreads(3))
+
+	var running, maxRunning atomic.Int64
+	parallelFor(8, 1, func(start, end int) {
+		parallelFor(8, 1, func(start, end int) {
+			n := running.Add(1)
+			for {
+				if current := maxRunning.Load(); n <= current || maxRunning.CompareAndSwap(current, n) {
+					break
+				}
+			}
+
+			time.Sleep(time.Millisecond)
+			running.Add(-1)
+		})
+	})
+
+	if n := maxRunning.Load(); n > 3 {
+		t.Fatalf("expected at most 3 goroutines for nested loops, got %d", n)
+	}
+
+	if n := activeWorkers.Load(); n != 0 {
+		t.Fatalf("expected all the workers to be done, got %d", n)
+	}
+}
@@ -0,0 +1,239 @@
+package tensor
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// Error returned by Parse for malformed input.
+type ParseError struct {
+	// Byte offset in the input at which the error was found.
+	Offset int
+
+	Message string
+}
+
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("tensor.Parse(): %s at offset %d", e.Message, e.Offset)
+}
+
+// Parses a tensor from a NumPy-style literal like "[[1, 2.5], [3, -4e-2]]".
+//
+// Elements can be separated by commas, whitespace or both, so the output of String() & fmt (without summarization) can
+// be parsed back, including the "Tensor(...)" wrapper & the shape and dtype printed by %+v, which are validated.
+func Parse[T Scalar](s string) (*Tensor[T], error) {
+	p := &parser[T]{input: s}
+	p.skipSpaces()
+
+	wrapped := p.consume("Tensor(")
+
+	p.skipSpaces()
+	value, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+
+	// shape & dtype, as printed by %+v
+	var printedShape []uint
+	printedDType := ""
+	p.skipSpaces()
+	if p.consume(",") {
+		p.skipSpaces()
+		if !p.consume("shape=") {
+			return nil, p.errorf("expected \"shape=\"")
+		}
+
+		if printedShape, err = p.parseShape(); err != nil {
+			return nil, err
+		}
+
+		p.skipSpaces()
+		if !p.consume(",") {
+			return nil, p.errorf("expected \",\"")
+		}
+
+		p.skipSpaces()
+		if !p.consume("dtype=") {
+			return nil, p.errorf("expected \"dtype=\"")
+		}
+
+		printedDType = p.parseToken()
+	}
+
+	p.skipSpaces()
+	if wrapped && !p.consume(")") {
+		return nil, p.errorf("expected \")\"")
+	}
+
+	p.skipSpaces()
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q", p.input[p.pos:])
+	}
+
+	// the parser only validates the syntax, so make sure that every list along a dimension is of the same size
+	shape := detectShape(value)
+	if len(shape) > 0 {
+		if err := checkShape(value, shape, 0); err != nil {
+			return nil, err
+		}
+	}
+
+	var zero T
+	if printedDType != "" && printedDType != reflect.TypeOf(zero).String() {
+		return nil, fmt.Errorf("tensor.Parse(): expected dtype %T, found %s", zero, printedDType)
+	}
+
+	// empty lists hide the dimensions after them, e.g. a tensor of shape [0 3] is printed as "[]"
+	if printedShape != nil && countElementsFromShape(shape) == 0 && countElementsFromShape(printedShape) == 0 &&
+		len(printedShape) > len(shape) && reflect.DeepEqual(printedShape[:len(shape)], shape) {
+		shape = printedShape
+	}
+
+	if printedShape != nil && !reflect.DeepEqual(printedShape, shape) {
+		return nil, fmt.Errorf("tensor.Parse(): printed shape %v doesn't match the detected shape %v", printedShape, shape)
+	}
+
+	data := make([]T, 0, countElementsFromShape(shape))
+	data = flattenParsedValue(value, data)
+
+	return fromData(shape, data), nil
+}
+
+// Like Parse(), but panics if the string can't be parsed. Useful for tests & package level variables.
+func MustParse[T Scalar](s string) *Tensor[T] {
+	t, err := Parse[T](s)
+	if err != nil {
+		panic(err.Error())
+	}
+
+	return t
+}
+
+type parser[T Scalar] struct {
+	input string
+	pos   int
+}
+
+func (p *parser[T]) errorf(format string, args ...interface{}) error {
+	return &ParseError{Offset: p.pos, Message: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser[T]) skipSpaces() {
+	for p.pos < len(p.input) && unicode.IsSpace(rune(p.input[p.pos])) {
+		p.pos++
+	}
+}
+
+// Consumes the prefix if the remaining input starts with it.
+func (p *parser[T]) consume(prefix string) bool {
+	if strings.HasPrefix(p.input[p.pos:], prefix) {
+		p.pos += len(prefix)
+		return true
+	}
+
+	return false
+}
+
+// Consumes everything up to the next whitespace, bracket, parenthesis or comma.
+func (p *parser[T]) parseToken() string {
+	start := p.pos
+	for p.pos < len(p.input) && !strings.ContainsRune("[](), \t\r\n", rune(p.input[p.pos])) {
+		p.pos++
+	}
+
+	return p.input[start:p.pos]
+}
+
+// Parses either a list (returned as []interface{}) or a single element of type T.
+func (p *parser[T]) parseValue() (interface{}, error) {
+	if p.pos >= len(p.input) {
+		return nil, p.errorf("unexpected end of input")
+	}
+
+	if p.input[p.pos] == '[' {
+		return p.parseList()
+	}
+
+	start := p.pos
+	token := p.parseToken()
+	if token == "" {
+		return nil, p.errorf("unexpected %q", p.input[p.pos])
+	}
+
+	if token == "..." {
+		return nil, &ParseError{Offset: start, Message: "summarized tensors cannot be parsed"}
+	}
+
+	value, err := parseScalar[T](token)
+	if err != nil {
+		return nil, &ParseError{Offset: start, Message: fmt.Sprintf("invalid element %q", token)}
+	}
+
+	return value, nil
+}
+
+func (p *parser[T]) parseList() (interface{}, error) {
+	// skip the '['
+	p.pos++
+
+	list := []interface{}{}
+	for {
+		p.skipSpaces()
+		if p.consume("]") {
+			break
+		}
+
+		if len(list) > 0 && p.consume(",") {
+			p.skipSpaces()
+		}
+
+		value, err := p.parseValue()
+		if err != nil {
+			return nil, err
+		}
+
+		list = append(list, value)
+	}
+
+	return list, nil
+}
+
+// Parses a shape printed as "[2 3]".
+func (p *parser[T]) parseShape() ([]uint, error) {
+	if !p.consume("[") {
+		return nil, p.errorf("expected \"[\"")
+	}
+
+	shape := []uint{}
+	for {
+		p.skipSpaces()
+		if p.consume("]") {
+			return shape, nil
+		}
+
+		start := p.pos
+		token := p.parseToken()
+		dim, err := parseScalar[uint](token)
+		if err != nil || token == "" {
+			return nil, &ParseError{Offset: start, Message: fmt.Sprintf("invalid dimension %q", token)}
+		}
+
+		shape = append(shape, dim)
+	}
+}
+
+// Appends the elements of the parsed value to data in row-major order.
+func flattenParsedValue[T Scalar](value interface{}, data []T) []T {
+	list, ok := value.([]interface{})
+	if !ok {
+		return append(data, value.(T))
+	}
+
+	for _, elem := range list {
+		data = flattenParsedValue(elem, data)
+	}
+
+	return data
+}
@@ -0,0 +1,83 @@
+package tensor
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	result, err := Parse[float64]("[[1, 2.5], [3, -4e-2]]")
+	if err != nil {
+		t.Fatalf("Parse(): unexpected error %v", err)
+	}
+
+	expected := WithValue[float64]([][]float64{{1, 2.5}, {3, -4e-2}})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	scalar, err := Parse[int]("Tensor(-7)")
+	if err != nil {
+		t.Fatalf("Parse(): unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(WithValue[int](-7), scalar) {
+		t.Fatalf("expected %v, got %v", WithValue[int](-7), scalar)
+	}
+}
+
+func TestParseRoundTrip(t *testing.T) {
+	tensor := WithValue[float32]([][][]float32{
+		{{1, -0.5}, {2.25, 3}},
+		{{-4, 5.125}, {6, 7}},
+	})
+
+	for _, format := range []string{"%v", "%+v"} {
+		s := fmt.Sprintf(format, tensor)
+
+		result, err := Parse[float32](s)
+		if err != nil {
+			t.Fatalf("Parse(%q): unexpected error %v", s, err)
+		}
+
+		if !reflect.DeepEqual(tensor, result) {
+			t.Fatalf("expected %v, got %v", tensor, result)
+		}
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		input  string
+		offset int
+	}{
+		{"[1, 2", 5},
+		{"[1, x]", 4},
+		{"[1 2] 3", 6},
+	}
+
+	for _, test := range tests {
+		_, err := Parse[int](test.input)
+
+		var parseErr *ParseError
+		if !errors.As(err, &parseErr) {
+			t.Fatalf("Parse(%q): expected a *ParseError, got %v", test.input, err)
+		}
+
+		if parseErr.Offset != test.offset {
+			t.Fatalf("Parse(%q): expected offset %d, got %d", test.input, test.offset, parseErr.Offset)
+		}
+	}
+
+	for _, input := range []string{"[[1, 2], [3]]", "[[1], []]"} {
+		if _, err := Parse[int](input); err == nil {
+			t.Fatalf("Parse(%q): expected an error for a non-homologous tensor", input)
+		}
+	}
+
+	if _, err := Parse[int]("Tensor([1 2], shape=[3], dtype=int)"); err == nil {
+		t.Fatalf("Parse(): expected an error for a mismatched shape")
+	}
+}
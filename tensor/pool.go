@@ -0,0 +1,186 @@
+package tensor
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"unsafe"
+)
+
+// Statistics of the memory allocated for tensor data, see GetAllocStats().
+type AllocStats struct {
+	// Number of buffers requested, e.g. by WithShape().
+	Allocations uint64
+
+	// Number of requests served by buffers returned with Release() or Arena.Reset().
+	PoolHits uint64
+
+	// Bytes allocated from the Go heap, i.e. not reused.
+	BytesAllocated uint64
+
+	// Bytes of reused buffers.
+	BytesReused uint64
+}
+
+var allocStats struct {
+	allocations    atomic.Uint64
+	poolHits       atomic.Uint64
+	bytesAllocated atomic.Uint64
+	bytesReused    atomic.Uint64
+}
+
+// Returns the statistics of the memory allocated for tensor data since the program started or ResetAllocStats() was
+// called. Comparing them before & after a training step shows how much pooling reduces the pressure on the GC.
+func GetAllocStats() AllocStats {
+	return AllocStats{
+		Allocations:    allocStats.allocations.Load(),
+		PoolHits:       allocStats.poolHits.Load(),
+		BytesAllocated: allocStats.bytesAllocated.Load(),
+		BytesReused:    allocStats.bytesReused.Load(),
+	}
+}
+
+// Resets the statistics returned by GetAllocStats() to zero.
+func ResetAllocStats() {
+	allocStats.allocations.Store(0)
+	allocStats.poolHits.Store(0)
+	allocStats.bytesAllocated.Store(0)
+	allocStats.bytesReused.Store(0)
+}
+
+// Key of the pool for buffers of a data type & length. Sizes are classed by their exact number of elements, because
+// training allocates tensors of the same few shapes over & over, and that wastes no memory on rounding.
+type poolKey struct {
+	dataType reflect.Type
+	length   int
+}
+
+// The *sync.Pool of released buffers for every poolKey.
+var pools sync.Map
+
+// Address ranges of memory that Release() must not put in a pool because tensors don't own it, like memory-mapped
+// files. Ranges rather than single addresses, so that sub-slices of that memory are recognized too.
+var unpoolable struct {
+	sync.RWMutex
+	ranges []addressRange
+}
+
+// The addresses from start (inclusive) to end (exclusive).
+type addressRange struct {
+	start, end uintptr
+}
+
+func rangeOf[T any](data []T) addressRange {
+	var zero T
+	start := uintptr(unsafe.Pointer(unsafe.SliceData(data)))
+	return addressRange{start, start + uintptr(len(data))*unsafe.Sizeof(zero)}
+}
+
+func (r addressRange) overlaps(other addressRange) bool {
+	return r.start < other.end && other.start < r.end
+}
+
+// Prevents Release() from pooling any part of the memory, until unregisterUnpoolable() is called with the same slice.
+func registerUnpoolable(memory []byte) {
+	unpoolable.Lock()
+	defer unpoolable.Unlock()
+
+	unpoolable.ranges = append(unpoolable.ranges, rangeOf(memory))
+}
+
+func unregisterUnpoolable(memory []byte) {
+	unpoolable.Lock()
+	defer unpoolable.Unlock()
+
+	unpoolable.ranges = slices.DeleteFunc(unpoolable.ranges, func(r addressRange) bool { return r == rangeOf(memory) })
+}
+
+func isUnpoolable[T any](data []T) bool {
+	unpoolable.RLock()
+	defer unpoolable.RUnlock()
+
+	return slices.ContainsFunc(unpoolable.ranges, rangeOf(data).overlaps)
+}
+
+// Returns a zeroed buffer of n elements for a tensor, from the pool of released buffers or the heap.
+func allocData[T Scalar](n int) []T {
+	allocStats.allocations.Add(1)
+
+	var zero T
+	bytes := uint64(n) * uint64(unsafe.Sizeof(zero))
+
+	if pool, ok := pools.Load(poolKey{reflect.TypeOf(zero), n}); ok {
+		if data, ok := pool.(*sync.Pool).Get().([]T); ok {
+			clear(data)
+			allocStats.poolHits.Add(1)
+			allocStats.bytesReused.Add(bytes)
+			return data
+		}
+	}
+
+	allocStats.bytesAllocated.Add(bytes)
+	return make([]T, n)
+}
+
+// Returns the buffer of the tensor to the pool so that tensors of the same size & type can reuse it, e.g. for the
+// temporaries of a training step. The tensor must not be used afterwards, and neither must the result of Value() or
+// Data().
+//
+// Tensors backed by memory-mapped files are only detached from their buffers.
+func (t *Tensor[T]) Release() {
+	data := t.data
+	t.data = nil
+	if len(data) == 0 || isUnpoolable(data) {
+		return
+	}
+
+	var zero T
+	pool, _ := pools.LoadOrStore(poolKey{reflect.TypeOf(zero), len(data)}, &sync.Pool{})
+	pool.(*sync.Pool).Put(data[:len(data):len(data)])
+}
+
+// Arena collects tensors to release them all at once with Reset(), e.g. the temporaries of a training step. Since the
+// next step allocates tensors of the same shapes, it then mostly reuses their buffers instead of allocating.
+//
+// Arenas are passed around explicitly, so tensors are only released if they were added to the arena, and goroutines
+// that don't use it, like data loaders, are unaffected. An arena can be shared by goroutines.
+//
+// For example:
+//
+//	arena := tensor.NewArena()
+//	for step := range steps {
+//		loss := train(step, arena) // which calls arena.Track() on the temporaries
+//		arena.Reset()
+//	}
+type Arena struct {
+	mu      sync.Mutex
+	tensors []interface{ Release() }
+}
+
+// Creates an empty arena.
+func NewArena() *Arena {
+	return &Arena{}
+}
+
+// Adds tensors to the arena, so that the next Reset() releases them. A tensor must not be added twice.
+func (a *Arena) Track(tensors ...interface{ Release() }) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.tensors = append(a.tensors, tensors...)
+}
+
+// Releases all the tensors added to the arena since the last reset. They must not be used afterwards, since their
+// buffers can be reused by any tensor.
+func (a *Arena) Reset() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, tensor := range a.tensors {
+		tensor.Release()
+	}
+
+	clear(a.tensors)
+	a.tensors = a.tensors[:0]
+}
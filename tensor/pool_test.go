@@ -0,0 +1,71 @@
+package tensor
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRelease(t *testing.T) {
+	ResetAllocStats()
+
+	// sync.Pool may drop buffers at any time, so only some of the allocations are expected to reuse them
+	for i := 0; i < 100; i++ {
+		tensor := WithShape[float64]([]uint{13, 7}, 1)
+		tensor.Release()
+
+		if tensor.data != nil {
+			t.Fatal("Release(): expected the tensor to be detached from its buffer")
+		}
+	}
+
+	stats := GetAllocStats()
+	if stats.Allocations != 100 || stats.PoolHits == 0 {
+		t.Fatalf("expected 100 allocations with pool hits, got %+v", stats)
+	}
+
+	if stats.BytesAllocated+stats.BytesReused != 100*13*7*8 {
+		t.Fatalf("expected %d bytes in total, got %+v", 100*13*7*8, stats)
+	}
+
+	// reused buffers are zeroed
+	tensor := WithShape[float64]([]uint{13, 7})
+	for _, value := range tensor.data {
+		if value != 0 {
+			t.Fatalf("expected a zeroed buffer, got %v", tensor.data)
+		}
+	}
+}
+
+func TestArena(t *testing.T) {
+	arena := NewArena()
+
+	step := func() *Tensor[float32] {
+		a := WithValue[float32]([][]float32{{1, 2}, {3, 4}})
+		b := a.Add(a)
+		c := b.Multiply(a)
+		arena.Track(a, b, c)
+		return MatrixMultiplication(a, c)
+	}
+
+	expected := step()
+	arena.Reset()
+
+	// tensors that weren't added to the arena are left alone
+	untracked := WithValue[float32]([]float32{5, 6, 7, 8})
+	ResetAllocStats()
+
+	// the second step reuses the buffers of the first one, as long as the pool doesn't drop them
+	result := step()
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	if stats := GetAllocStats(); stats.PoolHits == 0 {
+		t.Fatalf("expected the allocations to reuse the released buffers, got %+v", stats)
+	}
+
+	arena.Reset()
+	if !reflect.DeepEqual([]float32{5, 6, 7, 8}, untracked.data) {
+		t.Fatalf("Reset(): expected untracked tensors to keep their data, got %v", untracked.data)
+	}
+}
@@ -0,0 +1,210 @@
+package tensor
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// QuantizedScalar is a type that quantized values are stored as.
+type QuantizedScalar interface {
+	int8 | uint8
+}
+
+// QuantizedTensor stores 8-bit integers q that approximate real values as x = scale * (q - zeroPoint), using a quarter
+// of the memory of float32 values. The scale & zero point are either the same for the whole tensor, or there is one of
+// each per channel along an axis, like the output columns of a weight matrix.
+type QuantizedTensor[Q QuantizedScalar] struct {
+	values *Tensor[Q]
+
+	// a single scale & zero point for the whole tensor if axis is -1, otherwise one per index along the axis
+	scales     []float64
+	zeroPoints []int32
+	axis       int
+}
+
+// Quantizes the tensor with a single scale & zero point, chosen so that its range of values (including 0) maps to the
+// full range of Q. Panics if the range isn't finite, e.g. for infinities. NaNs don't count towards the range & are
+// quantized to the smallest value of Q. For example, Quantize[uint8](activations).
+func Quantize[Q QuantizedScalar, T Scalar](t *Tensor[T]) *QuantizedTensor[Q] {
+	return quantize[Q](t, -1)
+}
+
+// Quantizes the tensor with a separate scale & zero point for every channel along the axis, which is more accurate
+// when the channels have different ranges. Like for Quantize(), the range of every channel must be finite. Negative
+// axes count from the end.
+//
+// For example, QuantizePerChannel[int8](weights, 1) quantizes every neuron's weights of a Dense layer separately.
+func QuantizePerChannel[Q QuantizedScalar, T Scalar](t *Tensor[T], axis int) *QuantizedTensor[Q] {
+	return quantize[Q](t, normalizeAxis(axis, t.NDims()))
+}
+
+func quantize[Q QuantizedScalar, T Scalar](t *Tensor[T], axis int) *QuantizedTensor[Q] {
+	t = t.rowMajor()
+	q := &QuantizedTensor[Q]{values: WithShape[Q](slices.Clone(t.shape)), axis: axis}
+
+	numChannels := 1
+	if axis >= 0 {
+		numChannels = int(t.shape[axis])
+	}
+
+	// the range of every channel, always including 0 so that it's represented exactly, e.g. for padding. NaNs are
+	// skipped, since a single one would make the whole channel NaN.
+	low := make([]float64, numChannels)
+	high := make([]float64, numChannels)
+	for i, value := range t.data {
+		f := toFloat64(value)
+		if math.IsNaN(f) {
+			continue
+		}
+
+		c := q.channelOf(i)
+		low[c] = min(low[c], f)
+		high[c] = max(high[c], f)
+	}
+
+	qMin, qMax := quantizedRange[Q]()
+	q.scales = make([]float64, numChannels)
+	q.zeroPoints = make([]int32, numChannels)
+	for c := range q.scales {
+		scale := (high[c] - low[c]) / float64(qMax-qMin)
+		if math.IsInf(scale, 0) {
+			panic(fmt.Sprintf("Quantize(): cannot quantize the non-finite range [%v, %v] of channel %d", low[c], high[c], c))
+		}
+
+		// a channel of zeros
+		if scale == 0 {
+			scale = 1
+		}
+
+		q.scales[c] = scale
+		q.zeroPoints[c] = int32(clampQuantized(math.Round(float64(qMin)-low[c]/scale), qMin, qMax))
+	}
+
+	for i, value := range t.data {
+		c := q.channelOf(i)
+		quantized := math.Round(toFloat64(value)/q.scales[c]) + float64(q.zeroPoints[c])
+		q.values.data[i] = Q(clampQuantized(quantized, qMin, qMax))
+	}
+
+	return q
+}
+
+// Returns the smallest & largest values of Q.
+func quantizedRange[Q QuantizedScalar]() (int32, int32) {
+	var zero Q
+	if _, ok := any(zero).(int8); ok {
+		return math.MinInt8, math.MaxInt8
+	}
+
+	return 0, math.MaxUint8
+}
+
+// Rounds & clamps a value to [low, high]. NaNs become low.
+func clampQuantized(value float64, low, high int32) int32 {
+	if math.IsNaN(value) || value < float64(low) {
+		return low
+	}
+
+	if value > float64(high) {
+		return high
+	}
+
+	return int32(value)
+}
+
+// Returns the index of the scale & zero point for the element at index i of the data.
+func (q *QuantizedTensor[Q]) channelOf(i int) int {
+	if q.axis < 0 {
+		return 0
+	}
+
+	return (i / int(q.values.strides[q.axis])) % int(q.values.shape[q.axis])
+}
+
+// Returns the quantized values.
+func (q *QuantizedTensor[Q]) Values() *Tensor[Q] {
+	return q.values
+}
+
+// Returns the shape of the tensor.
+func (q *QuantizedTensor[Q]) Shape() []uint {
+	return q.values.shape
+}
+
+// Returns the axis along which the tensor was quantized per channel, or -1 if it was quantized per tensor.
+func (q *QuantizedTensor[Q]) Axis() int {
+	return q.axis
+}
+
+// Returns the scales, one per channel or a single one.
+func (q *QuantizedTensor[Q]) Scales() []float64 {
+	return q.scales
+}
+
+// Returns the zero points, one per channel or a single one.
+func (q *QuantizedTensor[Q]) ZeroPoints() []int32 {
+	return q.zeroPoints
+}
+
+// Converts the quantized tensor back to real values. For example, Dequantize[float64](q).
+func Dequantize[T Scalar, Q QuantizedScalar](q *QuantizedTensor[Q]) *Tensor[T] {
+	result := WithShape[T](slices.Clone(q.values.shape))
+	for i, value := range q.values.data {
+		c := q.channelOf(i)
+		result.data[i] = fromFloat64[T](q.scales[c] * float64(int32(value)-q.zeroPoints[c]))
+	}
+
+	return result
+}
+
+// Multiplies two quantized 2D matrices, accumulating the products of the integer values in int64 & scaling the sums
+// only once at the end. a must be quantized per tensor or per row (axis 0), and b per tensor or per column (axis 1),
+// so that every element of the result has a single scale.
+//
+// Every product fits in 17 bits, so the int64 sums can't overflow for any inner dimension that fits in memory.
+func QuantizedMatMul[Q1 QuantizedScalar, Q2 QuantizedScalar](a *QuantizedTensor[Q1], b *QuantizedTensor[Q2]) *Tensor[float64] {
+	if a.values.NDims() != 2 || b.values.NDims() != 2 {
+		panic("Both tensors must be 2D matrices!")
+	}
+
+	if a.values.shape[1] != b.values.shape[0] {
+		panic(ErrorMatMulConflictingDims)
+	}
+
+	if (a.axis != -1 && a.axis != 0) || (b.axis != -1 && b.axis != 1) {
+		panic(fmt.Sprintf("QuantizedMatMul(): a must be quantized per row & b per column, got axes %d & %d", a.axis, b.axis))
+	}
+
+	numRows, numInner, numCols := int(a.values.shape[0]), int(a.values.shape[1]), int(b.values.shape[1])
+
+	// subtract the zero points once, with b transposed so that both operands are read sequentially
+	aValues := make([]int32, len(a.values.data))
+	for i, value := range a.values.data {
+		aValues[i] = int32(value) - a.zeroPoints[a.channelOf(i)]
+	}
+
+	bValues := make([]int32, len(b.values.data))
+	for i, value := range b.values.data {
+		k, c := i/numCols, i%numCols
+		bValues[c*numInner+k] = int32(value) - b.zeroPoints[b.channelOf(i)]
+	}
+
+	result := WithShape[float64]([]uint{uint(numRows), uint(numCols)})
+	for r := 0; r < numRows; r++ {
+		aRow := aValues[r*numInner : (r+1)*numInner]
+		aScale := a.scales[min(r, len(a.scales)-1)]
+		for c := 0; c < numCols; c++ {
+			bColumn := bValues[c*numInner : (c+1)*numInner]
+
+			var sum int64
+			for k, value := range aRow {
+				sum += int64(value * bColumn[k])
+			}
+
+			result.data[r*numCols+c] = aScale * b.scales[min(c, len(b.scales)-1)] * float64(sum)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,90 @@
+package tensor
+
+import (
+	"math"
+	"testing"
+)
+
+func TestQuantize(t *testing.T) {
+	tensor := WithValue[float64]([][]float64{{-1, 0, 0.5}, {2, -0.25, 1}})
+
+	q := Quantize[uint8](tensor)
+	if len(q.Scales()) != 1 || q.Axis() != -1 {
+		t.Fatalf("expected a single scale, got %v", q.Scales())
+	}
+
+	// the range [-1, 2] maps to [0, 255]
+	expectedScale := 3.0 / 255
+	if math.Abs(q.Scales()[0]-expectedScale) > 1e-12 || q.ZeroPoints()[0] != 85 {
+		t.Fatalf("expected scale %v & zero point 85, got %v & %v", expectedScale, q.Scales()[0], q.ZeroPoints()[0])
+	}
+
+	if q.Values().Get(0, 1) != 85 {
+		t.Fatalf("expected 0 to be quantized exactly to the zero point, got %v", q.Values().Get(0, 1))
+	}
+
+	dequantized := Dequantize[float64](q)
+	if !AllClose(tensor, dequantized, Tolerance{ATol: expectedScale / 2}) {
+		t.Fatalf("expected %v, got %v", tensor, dequantized)
+	}
+}
+
+func TestQuantizePerChannel(t *testing.T) {
+	// the columns have very different ranges
+	tensor := WithValue[float32]([][]float32{{0.01, -100}, {-0.02, 50}, {0.005, 25}})
+
+	q := QuantizePerChannel[int8](tensor, -1)
+	if len(q.Scales()) != 2 || q.Axis() != 1 {
+		t.Fatalf("expected 2 scales along axis 1, got %v along %d", q.Scales(), q.Axis())
+	}
+
+	dequantized := Dequantize[float32](q)
+	for c := 0; c < 2; c++ {
+		for r := 0; r < 3; r++ {
+			if diff := math.Abs(float64(tensor.Get(r, c) - dequantized.Get(r, c))); diff > q.Scales()[c]/2+1e-6 {
+				t.Fatalf("(%d, %d): expected %v, got %v", r, c, tensor.Get(r, c), dequantized.Get(r, c))
+			}
+		}
+	}
+
+	// a NaN doesn't affect the range of its channel
+	tensor.Set([]int{2, 1}, float32(math.NaN()))
+	if scales := QuantizePerChannel[int8](tensor, -1).Scales(); scales[1] != q.Scales()[1] {
+		t.Fatalf("expected the scale %v despite the NaN, got %v", q.Scales()[1], scales[1])
+	}
+}
+
+func TestQuantizedMatMul(t *testing.T) {
+	a := WithValue[float64]([][]float64{{1, -0.5, 2}, {0, 1.5, -1}})
+	b := WithValue[float64]([][]float64{{0.2, -1}, {0.4, 0.5}, {-0.3, 2}})
+
+	qa := Quantize[uint8](a)
+	qb := QuantizePerChannel[int8](b, 1)
+
+	// the integer computation gives the same result as multiplying the dequantized matrices
+	expected := MatrixMultiplication(Dequantize[float64](qa), Dequantize[float64](qb))
+	result := QuantizedMatMul(qa, qb)
+	if !AllClose(expected, result, Tolerance{RTol: 1e-12, ATol: 1e-12}) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	if !AllClose(MatrixMultiplication(a, b), result, Tolerance{ATol: 0.05}) {
+		t.Fatalf("expected approximately %v, got %v", MatrixMultiplication(a, b), result)
+	}
+
+	// large inner dimensions, like a bag-of-words vocabulary, used to overflow int32 sums
+	ones := WithShape[float64]([]uint{1, 40000}, 1)
+	if result := QuantizedMatMul(Quantize[int8](ones), Quantize[int8](ones.Reshape(40000, 1))); math.Abs(result.Item()-40000) > 1 {
+		t.Fatalf("expected 40000 for a large inner dimension, got %v", result.Item())
+	}
+}
+
+func TestQuantizeNonFinite(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Quantize(): expected a panic for an infinite range")
+		}
+	}()
+
+	Quantize[uint8](WithValue[float64]([]float64{1, math.Inf(1)}))
+}
@@ -0,0 +1,183 @@
+package tensor
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// Maximum size of a safetensors JSON header that we are willing to read.
+const maxSafetensorsHeaderSize = 100 << 20
+
+// Key of the optional string-to-string metadata in a safetensors header.
+const safetensorsMetadataKey = "__metadata__"
+
+// Entry describing a single tensor in a safetensors header.
+type safetensorsEntry struct {
+	DType       string    `json:"dtype"`
+	Shape       []uint    `json:"shape"`
+	DataOffsets [2]uint64 `json:"data_offsets"`
+}
+
+// Reads named tensors from r in the safetensors format (https://github.com/huggingface/safetensors).
+//
+// Every tensor is converted to T, no matter which dtype it was stored as. So for example, F32 weights trained elsewhere
+// can be loaded as float64 tensors and passed to layers.DenseInit(). The optional metadata of the file is returned too.
+func LoadSafetensors[T Scalar](r io.Reader) (tensors map[string]*Tensor[T], metadata map[string]string, err error) {
+	// the file starts with the size of the header as a little-endian uint64
+	var headerSize uint64
+	if err := binary.Read(r, binary.LittleEndian, &headerSize); err != nil {
+		return nil, nil, fmt.Errorf("%s could not read header size: %w", ErrorInvalidSafetensors, err)
+	}
+
+	if headerSize > maxSafetensorsHeaderSize {
+		return nil, nil, fmt.Errorf("%s header size %d is too large", ErrorInvalidSafetensors, headerSize)
+	}
+
+	header := make([]byte, headerSize)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, nil, fmt.Errorf("%s could not read header: %w", ErrorInvalidSafetensors, err)
+	}
+
+	var rawEntries map[string]json.RawMessage
+	if err := json.Unmarshal(header, &rawEntries); err != nil {
+		return nil, nil, fmt.Errorf("%s could not parse header: %w", ErrorInvalidSafetensors, err)
+	}
+
+	// everything after the header is the byte buffer that holds the data of all the tensors
+	buffer, err := io.ReadAll(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s could not read data: %w", ErrorInvalidSafetensors, err)
+	}
+
+	tensors = make(map[string]*Tensor[T], len(rawEntries))
+	for name, raw := range rawEntries {
+		if name == safetensorsMetadataKey {
+			if err := json.Unmarshal(raw, &metadata); err != nil {
+				return nil, nil, fmt.Errorf("%s could not parse metadata: %w", ErrorInvalidSafetensors, err)
+			}
+
+			continue
+		}
+
+		var entry safetensorsEntry
+		if err := json.Unmarshal(raw, &entry); err != nil {
+			return nil, nil, fmt.Errorf("%s could not parse entry for tensor %q: %w", ErrorInvalidSafetensors, name, err)
+		}
+
+		t, err := decodeSafetensorsEntry[T](entry, buffer)
+		if err != nil {
+			return nil, nil, fmt.Errorf("tensor %q: %w", name, err)
+		}
+
+		tensors[name] = t
+	}
+
+	return tensors, metadata, nil
+}
+
+func decodeSafetensorsEntry[T Scalar](entry safetensorsEntry, buffer []byte) (*Tensor[T], error) {
+	size, ok := dtypeSizes[entry.DType]
+	if !ok {
+		return nil, fmt.Errorf("%s %q", ErrorUnsupportedDataType, entry.DType)
+	}
+
+	begin, end := entry.DataOffsets[0], entry.DataOffsets[1]
+	if begin > end || end > uint64(len(buffer)) {
+		return nil, fmt.Errorf("%s data offsets %v are out of bounds of a %d bytes buffer", ErrorInvalidSafetensors, entry.DataOffsets, len(buffer))
+	}
+
+	shape := entry.Shape
+	if shape == nil {
+		shape = []uint{}
+	}
+
+	numElements, err := checkedElementCount(shape, size)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", ErrorInvalidSafetensors, err)
+	}
+
+	expectedSize := uint64(numElements) * uint64(size)
+	if end-begin != expectedSize {
+		return nil, fmt.Errorf("%s expected %d bytes for shape %v of %s, found %d", ErrorInvalidSafetensors, expectedSize, shape, entry.DType, end-begin)
+	}
+
+	data, err := decodeElements[T](buffer[begin:end], entry.DType)
+	if err != nil {
+		return nil, err
+	}
+
+	return fromData(shape, data), nil
+}
+
+// Writes named tensors to w in the safetensors format (https://github.com/huggingface/safetensors).
+//
+// The tensors are stored with the dtype matching T (int & uint are stored as I64 & U64). The metadata is optional and
+// can be nil.
+func SaveSafetensors[T Scalar](w io.Writer, tensors map[string]*Tensor[T], metadata map[string]string) error {
+	// sort the names so that the output is deterministic
+	names := make([]string, 0, len(tensors))
+	for name := range tensors {
+		if name == safetensorsMetadataKey {
+			return fmt.Errorf("%s %q is a reserved tensor name", ErrorInvalidSafetensors, name)
+		}
+
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	dtype := dtypeOf[T]()
+	header := make(map[string]interface{}, len(tensors)+1)
+	if len(metadata) > 0 {
+		header[safetensorsMetadataKey] = metadata
+	}
+
+	buffers := make([][]byte, len(names))
+	offset := uint64(0)
+	for i, name := range names {
+		t := tensors[name]
+		buffers[i] = encodeElements(t.rowMajor().data)
+
+		shape := t.shape
+		if shape == nil {
+			shape = []uint{}
+		}
+
+		header[name] = safetensorsEntry{
+			DType:       dtype,
+			Shape:       shape,
+			DataOffsets: [2]uint64{offset, offset + uint64(len(buffers[i]))},
+		}
+
+		offset += uint64(len(buffers[i]))
+	}
+
+	headerBytes, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+
+	// pad the header with spaces so that the data buffer starts at an 8-byte aligned offset
+	for len(headerBytes)%8 != 0 {
+		headerBytes = append(headerBytes, ' ')
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, uint64(len(headerBytes))); err != nil {
+		return err
+	}
+
+	if _, err := w.Write(headerBytes); err != nil {
+		return err
+	}
+
+	for _, buf := range buffers {
+		if _, err := w.Write(buf); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
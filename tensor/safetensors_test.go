@@ -0,0 +1,85 @@
+package tensor
+
+import (
+	"bytes"
+	"encoding/binary"
+	"reflect"
+	"testing"
+)
+
+func TestSafetensorsRoundTrip(t *testing.T) {
+	tensors := map[string]*Tensor[float64]{
+		"weights": WithValue[float64]([][]float64{{0.5, -1.25}, {2, 3.75}, {-4, 0}}),
+		"biases":  WithValue[float64]([][]float64{{1, -1}}),
+	}
+	metadata := map[string]string{"format": "pt"}
+
+	var buf bytes.Buffer
+	if err := SaveSafetensors(&buf, tensors, metadata); err != nil {
+		t.Fatalf("SaveSafetensors(): unexpected error %v", err)
+	}
+
+	headerSize := binary.LittleEndian.Uint64(buf.Bytes())
+	if headerSize%8 != 0 {
+		t.Fatalf("header size: expected a multiple of 8, got %d", headerSize)
+	}
+
+	loaded, loadedMetadata, err := LoadSafetensors[float64](&buf)
+	if err != nil {
+		t.Fatalf("LoadSafetensors(): unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(tensors, loaded) {
+		t.Fatalf("expected %v, got %v", tensors, loaded)
+	}
+
+	if !reflect.DeepEqual(metadata, loadedMetadata) {
+		t.Fatalf("metadata: expected %v, got %v", metadata, loadedMetadata)
+	}
+}
+
+func TestSafetensorsConversion(t *testing.T) {
+	var buf bytes.Buffer
+	err := SaveSafetensors(&buf, map[string]*Tensor[float32]{
+		"x": WithValue[float32]([]float32{1.5, -2, 4}),
+	}, nil)
+	if err != nil {
+		t.Fatalf("SaveSafetensors(): unexpected error %v", err)
+	}
+
+	loaded, _, err := LoadSafetensors[float64](&buf)
+	if err != nil {
+		t.Fatalf("LoadSafetensors(): unexpected error %v", err)
+	}
+
+	expected := WithValue[float64]([]float64{1.5, -2, 4})
+	if !reflect.DeepEqual(expected, loaded["x"]) {
+		t.Fatalf("expected %v, got %v", expected, loaded["x"])
+	}
+}
+
+func TestSafetensorsInvalidOffsets(t *testing.T) {
+	header := []byte(`{"x":{"dtype":"F32","shape":[2,2],"data_offsets":[0,12]}}`)
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, uint64(len(header)))
+	buf.Write(header)
+	buf.Write(make([]byte, 12))
+
+	if _, _, err := LoadSafetensors[float32](&buf); err == nil {
+		t.Fatalf("LoadSafetensors(): expected an error for mismatched data offsets")
+	}
+}
+
+func TestSafetensorsOverflowingShape(t *testing.T) {
+	// 2^33 * 2^31 elements wrap around to 0 in 64 bits, which would match the empty data
+	header := []byte(`{"x":{"dtype":"F32","shape":[8589934592,2147483648],"data_offsets":[0,0]}}`)
+
+	var buf bytes.Buffer
+	binary.Write(&buf, binary.LittleEndian, uint64(len(header)))
+	buf.Write(header)
+
+	if _, _, err := LoadSafetensors[float32](&buf); err == nil {
+		t.Fatalf("LoadSafetensors(): expected an error for a shape with too many elements")
+	}
+}
@@ -2,7 +2,9 @@ package tensor
 
 import (
 	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 )
 
 type IntScalar interface {
@@ -31,8 +33,11 @@ type NumericScalarReal interface {
 // Scalar is a type that is only a single value, not a collection of values. For example, int, float64, etc.
 //
 // Note that it doesn't include booleans because they are not numeric, and thus numeric operations can't be performed on them.
+// Half-precision floats are included for storage, see Float16. Since they are stored as their bits, the Go operators &
+// conversions like T(0.5) don't work on their values, so generic code over Scalar must convert them explicitly, or
+// constrain T to NumericScalarReal like Map() does.
 type Scalar interface {
-	NumericScalarReal
+	NumericScalarReal | HalfScalar
 }
 
 // Checks if the provided value is a Scalar or not. Panics if it's a Scalar but not of the expected type.
@@ -57,3 +62,73 @@ func IsScalar[T interface{}](value interface{}) bool {
 		return false
 	}
 }
+
+// Parses a string as a Scalar of type T.
+func parseScalar[T Scalar](s string) (T, error) {
+	var zero T
+	switch any(zero).(type) {
+	case Float16, BFloat16:
+		// parsed as float64 to avoid rounding twice
+		v, err := strconv.ParseFloat(s, 64)
+		return fromFloat64[T](v), err
+	}
+
+	dataType := reflect.TypeOf(zero)
+	switch dataType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, dataType.Bits())
+		return T(v), err
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(s, 10, dataType.Bits())
+		return T(v), err
+	case reflect.Float32, reflect.Float64:
+		v, err := strconv.ParseFloat(s, dataType.Bits())
+		return T(v), err
+	default:
+		panic(fmt.Sprintf("Unsupported type %T for parsing", zero))
+	}
+}
+
+// Formats a Scalar using the shortest representation that parses back to the same value.
+func formatScalar[T Scalar](value T) string {
+	switch v := any(value).(type) {
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case Float16:
+		return v.String()
+	case BFloat16:
+		return v.String()
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// Converts a Scalar to float64, by value for the half-precision types rather than by bits like float64(value) would.
+// 64-bit integers beyond 2^53 are rounded.
+func ToFloat64[T Scalar](value T) float64 {
+	return toFloat64(value)
+}
+
+// Converts a Scalar to float64.
+func toFloat64[T Scalar](value T) float64 {
+	switch v := any(value).(type) {
+	case Float16:
+		return v.Float64()
+	case BFloat16:
+		return v.Float64()
+	default:
+		return float64(value)
+	}
+}
+
+// Checks if the value is a NaN.
+func isNaN[T Scalar](value T) bool {
+	if isHalf[T]() {
+		return math.IsNaN(toFloat64(value))
+	}
+
+	// only NaNs are not equal to themselves
+	return value != value
+}
@@ -0,0 +1,302 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Side of SearchSorted() to insert values at when they are equal to elements of the sorted tensor.
+type Side int
+
+const (
+	// Insert before the equal elements, i.e. find the first index i such that value <= sorted[i].
+	SideLeft Side = iota
+
+	// Insert after the equal elements, i.e. find the first index i such that value < sorted[i].
+	SideRight
+)
+
+// Compares two Scalars for sorting in ascending order. Like NumPy, NaNs are sorted after everything else.
+func compareScalars[T Scalar](a, b T) int {
+	// the operators compare the bits of half-precision values, not the values
+	if isHalf[T]() {
+		return compareScalars(toFloat64(a), toFloat64(b))
+	}
+
+	// only NaNs are not equal to themselves
+	aIsNaN, bIsNaN := a != a, b != b
+	switch {
+	case aIsNaN && bIsNaN:
+		return 0
+	case aIsNaN:
+		return 1
+	case bIsNaN:
+		return -1
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Calls f with a copy of every 1D lane of t along the axis & writes the output filled by f to the corresponding lane of
+// result, which must have the same shape as t except possibly along the axis.
+func transformLanes[T Scalar, R Scalar](t *Tensor[T], result *Tensor[R], axis int, f func(lane []T, output []R)) {
+	size := int(t.shape[axis])
+	stride := int(t.strides[axis])
+	resultSize := int(result.shape[axis])
+	resultStride := int(result.strides[axis])
+	resultStarts := laneStarts(result.shape, result.strides, axis)
+
+	lane := make([]T, size)
+	output := make([]R, resultSize)
+	for i, start := range laneStarts(t.shape, t.strides, axis) {
+		for j := range lane {
+			lane[j] = t.data[start+j*stride]
+		}
+
+		f(lane, output)
+
+		for j, value := range output {
+			result.data[resultStarts[i]+j*resultStride] = value
+		}
+	}
+}
+
+// Returns a copy of the tensor sorted in ascending order along the axis. NaNs are placed at the end. Negative axes
+// count from the end.
+func Sort[T Scalar](t *Tensor[T], axis int) *Tensor[T] {
+	axis = normalizeAxis(axis, t.NDims())
+
+	result := WithShape[T](slices.Clone(t.shape))
+	transformLanes(t, result, axis, func(lane, output []T) {
+		slices.SortFunc(lane, compareScalars[T])
+		copy(output, lane)
+	})
+
+	return result
+}
+
+// Returns the indices that would sort the tensor along the axis. The order of equal elements is not guaranteed to be
+// preserved, see ArgSortStable(). Negative axes count from the end.
+func ArgSort[T Scalar](t *Tensor[T], axis int) *Tensor[int] {
+	return argSort(t, axis, false)
+}
+
+// Like ArgSort(), but equal elements keep their original order.
+func ArgSortStable[T Scalar](t *Tensor[T], axis int) *Tensor[int] {
+	return argSort(t, axis, true)
+}
+
+func argSort[T Scalar](t *Tensor[T], axis int, stable bool) *Tensor[int] {
+	axis = normalizeAxis(axis, t.NDims())
+
+	result := WithShape[int](slices.Clone(t.shape))
+	transformLanes(t, result, axis, func(lane []T, indices []int) {
+		for i := range indices {
+			indices[i] = i
+		}
+
+		compare := func(i, j int) int {
+			return compareScalars(lane[i], lane[j])
+		}
+
+		if stable {
+			slices.SortStableFunc(indices, compare)
+		} else {
+			slices.SortFunc(indices, compare)
+		}
+	})
+
+	return result
+}
+
+// Returns the k largest elements along the axis in descending order, along with their indices. Equal elements are
+// ordered by their index. Negative axes count from the end.
+func TopK[T Scalar](t *Tensor[T], k int, axis int) (values *Tensor[T], indices *Tensor[int]) {
+	axis = normalizeAxis(axis, t.NDims())
+	if k < 1 || k > int(t.shape[axis]) {
+		panic(fmt.Sprintf("TopK(): k must be between 1 and %d, got %d", t.shape[axis], k))
+	}
+
+	resultShape := slices.Clone(t.shape)
+	resultShape[axis] = uint(k)
+
+	indices = WithShape[int](resultShape)
+	transformLanes(t, indices, axis, func(lane []T, topIndices []int) {
+		all := make([]int, len(lane))
+		for i := range all {
+			all[i] = i
+		}
+
+		// descending order, but NaNs still go last
+		slices.SortStableFunc(all, func(i, j int) int {
+			if isNaN(lane[i]) || isNaN(lane[j]) {
+				return compareScalars(lane[i], lane[j])
+			}
+
+			return compareScalars(lane[j], lane[i])
+		})
+
+		copy(topIndices, all)
+	})
+
+	// the values are picked using the indices, lane by lane
+	values = WithShape[T](slices.Clone(resultShape))
+	valueStarts := laneStarts(values.shape, values.strides, axis)
+	indexStarts := laneStarts(indices.shape, indices.strides, axis)
+	sourceStarts := laneStarts(t.shape, t.strides, axis)
+	for i := range valueStarts {
+		for j := 0; j < k; j++ {
+			index := indices.data[indexStarts[i]+j*int(indices.strides[axis])]
+			values.data[valueStarts[i]+j*int(values.strides[axis])] = t.data[sourceStarts[i]+index*int(t.strides[axis])]
+		}
+	}
+
+	return values, indices
+}
+
+// Returns a copy of the tensor rearranged along the axis so that the element at position kth is the one that would be
+// there if the lane was sorted, with all smaller elements before it & all larger ones after it, in no particular order.
+// Negative axes count from the end.
+func Partition[T Scalar](t *Tensor[T], kth int, axis int) *Tensor[T] {
+	axis = normalizeAxis(axis, t.NDims())
+	kth = normalizePartitionIndex(kth, int(t.shape[axis]))
+
+	result := WithShape[T](slices.Clone(t.shape))
+	transformLanes(t, result, axis, func(lane, output []T) {
+		indices := make([]int, len(lane))
+		for i := range indices {
+			indices[i] = i
+		}
+
+		quickSelect(indices, kth, func(i, j int) int { return compareScalars(lane[i], lane[j]) })
+		for i, index := range indices {
+			output[i] = lane[index]
+		}
+	})
+
+	return result
+}
+
+// Returns the indices that would partition the tensor along the axis, see Partition().
+func ArgPartition[T Scalar](t *Tensor[T], kth int, axis int) *Tensor[int] {
+	axis = normalizeAxis(axis, t.NDims())
+	kth = normalizePartitionIndex(kth, int(t.shape[axis]))
+
+	result := WithShape[int](slices.Clone(t.shape))
+	transformLanes(t, result, axis, func(lane []T, indices []int) {
+		for i := range indices {
+			indices[i] = i
+		}
+
+		quickSelect(indices, kth, func(i, j int) int { return compareScalars(lane[i], lane[j]) })
+	})
+
+	return result
+}
+
+func normalizePartitionIndex(kth, size int) int {
+	if kth < -size || kth >= size {
+		panic(fmt.Sprintf("kth %d is out of bounds for an axis of size %d", kth, size))
+	}
+
+	if kth < 0 {
+		kth += size
+	}
+
+	return kth
+}
+
+// Rearranges items so that items[k] is the item that would be there if they were sorted, with smaller items before it
+// & larger ones after it.
+func quickSelect(items []int, k int, compare func(a, b int) int) {
+	low, high := 0, len(items)-1
+	for low < high {
+		// median of three as the pivot, moved to the end
+		mid := low + (high-low)/2
+		if compare(items[mid], items[low]) < 0 {
+			items[mid], items[low] = items[low], items[mid]
+		}
+		if compare(items[high], items[low]) < 0 {
+			items[high], items[low] = items[low], items[high]
+		}
+		if compare(items[mid], items[high]) < 0 {
+			items[mid], items[high] = items[high], items[mid]
+		}
+
+		// three-way partition into items[low:lt] < pivot, items[lt:gt+1] == pivot & items[gt+1:high+1] > pivot, so
+		// that equal items, e.g. a lane of zeros, are all settled at once instead of one per pass
+		pivot := items[high]
+		lt, i, gt := low, low, high
+		for i <= gt {
+			switch c := compare(items[i], pivot); {
+			case c < 0:
+				items[i], items[lt] = items[lt], items[i]
+				lt++
+				i++
+			case c > 0:
+				items[i], items[gt] = items[gt], items[i]
+				gt--
+			default:
+				i++
+			}
+		}
+
+		switch {
+		case k < lt:
+			high = lt - 1
+		case k > gt:
+			low = gt + 1
+		default:
+			return
+		}
+	}
+}
+
+// Finds the indices in the 1D sorted tensor at which the values should be inserted to keep it sorted, like
+// numpy.searchsorted(). The result has the same shape as values.
+func SearchSorted[T Scalar](sorted, values *Tensor[T], side Side) *Tensor[int] {
+	if sorted.NDims() != 1 {
+		panic(fmt.Sprintf("SearchSorted(): expected a 1D sorted tensor, got shape %v", sorted.shape))
+	}
+
+	n := int(sorted.shape[0])
+	stride := int(sorted.strides[0])
+	at := func(i int) T { return sorted.data[i*stride] }
+
+	result := WithShape[int](slices.Clone(values.shape))
+	for i, value := range values.rowMajor().data {
+		if side == SideLeft {
+			result.data[i] = sort.Search(n, func(j int) bool { return compareScalars(value, at(j)) <= 0 })
+		} else {
+			result.data[i] = sort.Search(n, func(j int) bool { return compareScalars(value, at(j)) < 0 })
+		}
+	}
+
+	return result
+}
+
+// Returns the sorted unique elements of the (flattened) tensor & the number of times each of them occurs.
+func Unique[T Scalar](t *Tensor[T]) (values *Tensor[T], counts *Tensor[int]) {
+	sorted := slices.Clone(t.rowMajor().data)
+	slices.SortFunc(sorted, compareScalars[T])
+
+	var uniqueValues []T
+	var uniqueCounts []int
+	for i, value := range sorted {
+		if i > 0 && compareScalars(value, sorted[i-1]) == 0 {
+			uniqueCounts[len(uniqueCounts)-1]++
+			continue
+		}
+
+		uniqueValues = append(uniqueValues, value)
+		uniqueCounts = append(uniqueCounts, 1)
+	}
+
+	numUnique := uint(len(uniqueValues))
+	return fromData([]uint{numUnique}, uniqueValues), fromData([]uint{numUnique}, uniqueCounts)
+}
@@ -0,0 +1,136 @@
+package tensor
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	tensor := WithValue[int]([][]int{{3, 1, 2}, {0, 5, -1}})
+
+	expected := WithValue[int]([][]int{{1, 2, 3}, {-1, 0, 5}})
+	result := Sort(tensor, -1)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[int]([][]int{{0, 1, -1}, {3, 5, 2}})
+	result = Sort(tensor, 0)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	floats := WithValue[float64]([]float64{2, math.NaN(), -1})
+	sorted := Sort(floats, 0)
+	if sorted.Get(0) != -1 || sorted.Get(1) != 2 || !math.IsNaN(sorted.Get(2)) {
+		t.Fatalf("expected NaN to be sorted last, got %v", sorted)
+	}
+}
+
+func TestArgSortStable(t *testing.T) {
+	tensor := WithValue[int]([]int{2, 1, 2, 1, 0})
+
+	expected := WithValue[int]([]int{4, 1, 3, 0, 2})
+	result := ArgSortStable(tensor, 0)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestTopK(t *testing.T) {
+	tensor := WithValue[float64]([][]float64{{0.1, 0.7, 0.2}, {0.5, 0.1, 0.4}})
+
+	expectedValues := WithValue[float64]([][]float64{{0.7, 0.2}, {0.5, 0.4}})
+	expectedIndices := WithValue[int]([][]int{{1, 2}, {0, 2}})
+	values, indices := TopK(tensor, 2, 1)
+	if !reflect.DeepEqual(expectedValues, values) {
+		t.Fatalf("values: expected %v, got %v", expectedValues, values)
+	}
+
+	if !reflect.DeepEqual(expectedIndices, indices) {
+		t.Fatalf("indices: expected %v, got %v", expectedIndices, indices)
+	}
+}
+
+func TestPartition(t *testing.T) {
+	tensor := WithValue[int]([]int{7, 2, 9, 4, 1, 8, 3})
+
+	for kth := 0; kth < 7; kth++ {
+		partitioned := Partition(tensor, kth, 0)
+		pivot := partitioned.Get(kth)
+		if pivot != Sort(tensor, 0).Get(kth) {
+			t.Fatalf("kth %d: expected %d at position %d, got %v", kth, Sort(tensor, 0).Get(kth), kth, partitioned)
+		}
+
+		for i := 0; i < 7; i++ {
+			if (i < kth && partitioned.Get(i) > pivot) || (i > kth && partitioned.Get(i) < pivot) {
+				t.Fatalf("kth %d: %v is not partitioned", kth, partitioned)
+			}
+		}
+
+		indices := ArgPartition(tensor, kth, 0)
+		if tensor.Get(indices.Get(kth)) != pivot {
+			t.Fatalf("kth %d: ArgPartition() expected index of %d, got %v", kth, pivot, indices)
+		}
+	}
+
+	// few distinct values, like labels, used to take quadratic time since equal items were settled one per pass
+	const n = 200000
+	labels := WithShape[int]([]uint{n})
+	for i := 0; i < n; i++ {
+		labels.Set([]int{i}, i%3)
+	}
+
+	partitioned := Partition(labels, n/2, 0)
+	for i := 0; i < n; i++ {
+		if (i < n/2 && partitioned.Get(i) > 1) || (i > n/2 && partitioned.Get(i) < 1) || (i == n/2 && partitioned.Get(i) != 1) {
+			t.Fatalf("expected the labels to be partitioned around 1, got %d at position %d", partitioned.Get(i), i)
+		}
+	}
+
+	if zeros := ArgPartition(WithShape[float64]([]uint{n}), n/2, 0); zeros.Get(n/2) < 0 || zeros.Get(n/2) >= n {
+		t.Fatalf("ArgPartition(): expected an index in [0, %d), got %d", n, zeros.Get(n/2))
+	}
+}
+
+func TestSearchSorted(t *testing.T) {
+	sorted := WithValue[int]([]int{1, 2, 2, 3, 5})
+	values := WithValue[int]([][]int{{2, 4}, {0, 6}})
+
+	expected := WithValue[int]([][]int{{1, 4}, {0, 5}})
+	result := SearchSorted(sorted, values, SideLeft)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = WithValue[int]([][]int{{3, 4}, {0, 5}})
+	result = SearchSorted(sorted, values, SideRight)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	if result := SearchSorted(sorted, WithValue[int](4), SideLeft); result.NDims() != 0 || result.Item() != 4 {
+		t.Fatalf("expected a 0D tensor holding 4, got %v", result)
+	}
+}
+
+func TestUnique(t *testing.T) {
+	tensor := WithValue[int]([][]int{{3, 1, 3}, {2, 1, 3}})
+
+	expectedValues := WithValue[int]([]int{1, 2, 3})
+	expectedCounts := WithValue[int]([]int{2, 1, 3})
+	values, counts := Unique(tensor)
+	if !reflect.DeepEqual(expectedValues, values) {
+		t.Fatalf("values: expected %v, got %v", expectedValues, values)
+	}
+
+	if !reflect.DeepEqual(expectedCounts, counts) {
+		t.Fatalf("counts: expected %v, got %v", expectedCounts, counts)
+	}
+
+	values, counts = Unique(WithValue[int](7))
+	if !reflect.DeepEqual(WithValue[int]([]int{7}), values) || !reflect.DeepEqual(WithValue[int]([]int{1}), counts) {
+		t.Fatalf("expected [7] & [1] for a 0D tensor, got %v & %v", values, counts)
+	}
+}
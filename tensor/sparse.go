@@ -0,0 +1,512 @@
+package tensor
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+)
+
+// Storage format of a SparseTensor.
+type SparseFormat int
+
+const (
+	// Coordinate format: a (row, column, value) triplet per element. Convenient for construction.
+	SparseCOO SparseFormat = iota
+
+	// Compressed sparse row format: the elements of each row are stored together. Efficient for row slicing &
+	// multiplying with a dense matrix on the right.
+	SparseCSR
+
+	// Compressed sparse column format: the elements of each column are stored together.
+	SparseCSC
+)
+
+func (f SparseFormat) String() string {
+	switch f {
+	case SparseCOO:
+		return "COO"
+	case SparseCSR:
+		return "CSR"
+	case SparseCSC:
+		return "CSC"
+	default:
+		return fmt.Sprintf("SparseFormat(%d)", int(f))
+	}
+}
+
+// SparseTensor is a 2D matrix that only stores its non-zero elements.
+type SparseTensor[T Scalar] struct {
+	format SparseFormat
+	shape  []uint
+
+	// COO: the row of every element, which are sorted in row-major order. Unused otherwise.
+	rows []int
+
+	// COO & CSR: the column of every element. CSC: the row of every element.
+	indices []int
+
+	// CSR: elements of row i are at indptr[i]:indptr[i+1]. CSC: same for columns. Unused for COO.
+	indptr []int
+
+	values []T
+}
+
+// Creates a sparse matrix in COO format from the coordinates & values of its non-zero elements. The values of
+// duplicate coordinates are summed.
+func NewCOO[T Scalar](shape []uint, rows, cols []int, values []T) *SparseTensor[T] {
+	if isHalf[T]() {
+		return castSparse[T](NewCOO(shape, rows, cols, castSlice[float32](values)))
+	}
+
+	if len(shape) != 2 {
+		panic(fmt.Sprintf("NewCOO(): sparse tensors must be 2D, got shape %v", shape))
+	}
+
+	if len(rows) != len(values) || len(cols) != len(values) {
+		panic(fmt.Sprintf("NewCOO(): got %d rows, %d columns & %d values", len(rows), len(cols), len(values)))
+	}
+
+	for i := range values {
+		if rows[i] < 0 || rows[i] >= int(shape[0]) || cols[i] < 0 || cols[i] >= int(shape[1]) {
+			panic(fmt.Sprintf("NewCOO(): coordinates (%d, %d) are out of bounds for shape %v", rows[i], cols[i], shape))
+		}
+	}
+
+	// sort in row-major order & sum the duplicates
+	order := make([]int, len(values))
+	for i := range order {
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		i, j := order[a], order[b]
+		return rows[i] < rows[j] || (rows[i] == rows[j] && cols[i] < cols[j])
+	})
+
+	s := &SparseTensor[T]{format: SparseCOO, shape: slices.Clone(shape)}
+	for _, i := range order {
+		n := len(s.values)
+		if n > 0 && s.rows[n-1] == rows[i] && s.indices[n-1] == cols[i] {
+			s.values[n-1] += values[i]
+			continue
+		}
+
+		s.rows = append(s.rows, rows[i])
+		s.indices = append(s.indices, cols[i])
+		s.values = append(s.values, values[i])
+	}
+
+	return s
+}
+
+// Converts the values of a sparse tensor to another Scalar type. The indices are shared with s.
+func castSparse[To Scalar, From Scalar](s *SparseTensor[From]) *SparseTensor[To] {
+	return &SparseTensor[To]{
+		format:  s.format,
+		shape:   s.shape,
+		rows:    s.rows,
+		indices: s.indices,
+		indptr:  s.indptr,
+		values:  castSlice[To](s.values),
+	}
+}
+
+// Creates a sparse matrix in CSR format from the non-zero elements of a 2D tensor. Like for float32, -0 isn't stored.
+func SparseFromDense[T Scalar](t *Tensor[T]) *SparseTensor[T] {
+	// -0 has non-zero bits
+	if isHalf[T]() {
+		return castSparse[T](SparseFromDense(Cast[float32](t)))
+	}
+
+	if t.NDims() != 2 {
+		panic(fmt.Sprintf("SparseFromDense(): sparse tensors must be 2D, got shape %v", t.shape))
+	}
+
+	s := &SparseTensor[T]{format: SparseCSR, shape: slices.Clone(t.shape), indptr: make([]int, t.shape[0]+1)}
+	for r := 0; r < int(t.shape[0]); r++ {
+		for c := 0; c < int(t.shape[1]); c++ {
+			if value := t.UnsafeGet(r, c); value != 0 {
+				s.indices = append(s.indices, c)
+				s.values = append(s.values, value)
+			}
+		}
+
+		s.indptr[r+1] = len(s.values)
+	}
+
+	return s
+}
+
+// Returns the storage format of the sparse tensor.
+func (s *SparseTensor[T]) Format() SparseFormat {
+	return s.format
+}
+
+// Returns the shape of the sparse tensor.
+func (s *SparseTensor[T]) Shape() []uint {
+	return s.shape
+}
+
+// Returns the number of stored elements.
+func (s *SparseTensor[T]) NNZ() int {
+	return len(s.values)
+}
+
+// Returns the element at the given row & column. It's found with a binary search in the stored elements, of its row
+// for CSR, of its column for CSC & of all of them for COO, so it doesn't convert or allocate anything.
+func (s *SparseTensor[T]) At(row, col int) T {
+	if row < 0 || row >= int(s.shape[0]) || col < 0 || col >= int(s.shape[1]) {
+		panic(fmt.Sprintf("Index (%d, %d) is out of bounds for shape %v", row, col, s.shape))
+	}
+
+	var i int
+	found := false
+	switch s.format {
+	case SparseCOO:
+		i = sort.Search(len(s.values), func(j int) bool {
+			return s.rows[j] > row || (s.rows[j] == row && s.indices[j] >= col)
+		})
+		found = i < len(s.values) && s.rows[i] == row && s.indices[i] == col
+	case SparseCSR:
+		start := s.indptr[row]
+		i, found = slices.BinarySearch(s.indices[start:s.indptr[row+1]], col)
+		i += start
+	default:
+		start := s.indptr[col]
+		i, found = slices.BinarySearch(s.indices[start:s.indptr[col+1]], row)
+		i += start
+	}
+
+	if found {
+		return s.values[i]
+	}
+
+	return 0
+}
+
+// Calls f with the row, column & value of every stored element, in row-major order.
+func (s *SparseTensor[T]) forEach(f func(row, col int, value T)) {
+	coo := s.ToCOO()
+	for i, value := range coo.values {
+		f(coo.rows[i], coo.indices[i], value)
+	}
+}
+
+// Returns the sparse tensor in COO format. It's returned as is if it already is.
+func (s *SparseTensor[T]) ToCOO() *SparseTensor[T] {
+	switch s.format {
+	case SparseCOO:
+		return s
+	case SparseCSR:
+		coo := &SparseTensor[T]{
+			format:  SparseCOO,
+			shape:   s.shape,
+			rows:    make([]int, len(s.values)),
+			indices: slices.Clone(s.indices),
+			values:  slices.Clone(s.values),
+		}
+
+		for r := 0; r < int(s.shape[0]); r++ {
+			for i := s.indptr[r]; i < s.indptr[r+1]; i++ {
+				coo.rows[i] = r
+			}
+		}
+
+		return coo
+	default:
+		// transpose the CSC matrix's elements into row-major order
+		rows := make([]int, len(s.values))
+		cols := make([]int, len(s.values))
+		for c := 0; c < int(s.shape[1]); c++ {
+			for i := s.indptr[c]; i < s.indptr[c+1]; i++ {
+				rows[i] = s.indices[i]
+				cols[i] = c
+			}
+		}
+
+		return NewCOO(s.shape, rows, cols, s.values)
+	}
+}
+
+// Returns the sparse tensor in CSR format. It's returned as is if it already is.
+func (s *SparseTensor[T]) ToCSR() *SparseTensor[T] {
+	if s.format == SparseCSR {
+		return s
+	}
+
+	coo := s.ToCOO()
+	csr := &SparseTensor[T]{
+		format:  SparseCSR,
+		shape:   s.shape,
+		indices: slices.Clone(coo.indices),
+		values:  slices.Clone(coo.values),
+		indptr:  make([]int, s.shape[0]+1),
+	}
+
+	// count the elements of every row, then accumulate the counts into the row pointers
+	for _, r := range coo.rows {
+		csr.indptr[r+1]++
+	}
+
+	for r := 0; r < int(s.shape[0]); r++ {
+		csr.indptr[r+1] += csr.indptr[r]
+	}
+
+	return csr
+}
+
+// Returns the sparse tensor in CSC format. It's returned as is if it already is.
+func (s *SparseTensor[T]) ToCSC() *SparseTensor[T] {
+	if s.format == SparseCSC {
+		return s
+	}
+
+	coo := s.ToCOO()
+	csc := &SparseTensor[T]{
+		format:  SparseCSC,
+		shape:   s.shape,
+		indices: make([]int, len(coo.values)),
+		values:  make([]T, len(coo.values)),
+		indptr:  make([]int, s.shape[1]+1),
+	}
+
+	for _, c := range coo.indices {
+		csc.indptr[c+1]++
+	}
+
+	for c := 0; c < int(s.shape[1]); c++ {
+		csc.indptr[c+1] += csc.indptr[c]
+	}
+
+	// the COO elements are in row-major order, so the rows within each column end up sorted
+	next := slices.Clone(csc.indptr[:len(csc.indptr)-1])
+	for i, c := range coo.indices {
+		csc.indices[next[c]] = coo.rows[i]
+		csc.values[next[c]] = coo.values[i]
+		next[c]++
+	}
+
+	return csc
+}
+
+// Converts the sparse tensor to a dense one.
+func (s *SparseTensor[T]) ToDense() *Tensor[T] {
+	result := WithShape[T](slices.Clone(s.shape))
+	s.forEach(func(row, col int, value T) {
+		result.data[row*int(s.shape[1])+col] = value
+	})
+
+	return result
+}
+
+// Returns the rows start (inclusive) to end (exclusive) in CSR format, for example to get a mini-batch.
+func (s *SparseTensor[T]) SliceRows(start, end int) *SparseTensor[T] {
+	if start < 0 || end > int(s.shape[0]) || start >= end {
+		panic(fmt.Sprintf("SliceRows(): invalid range [%d, %d) for shape %v", start, end, s.shape))
+	}
+
+	csr := s.ToCSR()
+	first, last := csr.indptr[start], csr.indptr[end]
+
+	indptr := make([]int, end-start+1)
+	for i := range indptr {
+		indptr[i] = csr.indptr[start+i] - first
+	}
+
+	return &SparseTensor[T]{
+		format:  SparseCSR,
+		shape:   []uint{uint(end - start), s.shape[1]},
+		indices: slices.Clone(csr.indices[first:last]),
+		values:  slices.Clone(csr.values[first:last]),
+		indptr:  indptr,
+	}
+}
+
+// Returns a new sparse tensor in CSR format with f applied to every stored element. The elements that are not stored
+// stay zero, so f should map zero to zero. Like for Map(), half-precision tensors must be cast to float32 first.
+func SparseMap[T NumericScalarReal](s *SparseTensor[T], f func(value T) T) *SparseTensor[T] {
+	return sparseMap(s, f)
+}
+
+// Like SparseMap(), for any Scalar.
+func sparseMap[T Scalar](s *SparseTensor[T], f func(value T) T) *SparseTensor[T] {
+	csr := s.ToCSR()
+	result := &SparseTensor[T]{
+		format:  SparseCSR,
+		shape:   csr.shape,
+		indices: slices.Clone(csr.indices),
+		indptr:  slices.Clone(csr.indptr),
+		values:  make([]T, len(csr.values)),
+	}
+
+	for i, value := range csr.values {
+		result.values[i] = f(value)
+	}
+
+	return result
+}
+
+// Returns the sparse tensor multiplied by a scalar.
+func (s *SparseTensor[T]) Scale(factor T) *SparseTensor[T] {
+	if isHalf[T]() {
+		return castSparse[T](castSparse[float32](s).Scale(float32(toFloat64(factor))))
+	}
+
+	return sparseMap(s, func(value T) T { return value * factor })
+}
+
+// Adds two sparse tensors of the same shape. The result is in CSR format.
+func SparseAdd[T Scalar](s1, s2 *SparseTensor[T]) *SparseTensor[T] {
+	if isHalf[T]() {
+		return castSparse[T](SparseAdd(castSparse[float32](s1), castSparse[float32](s2)))
+	}
+
+	return mergeSparse(s1, s2, true, func(a, b T) T { return a + b })
+}
+
+// Subtracts two sparse tensors of the same shape. The result is in CSR format.
+func SparseSubtract[T Scalar](s1, s2 *SparseTensor[T]) *SparseTensor[T] {
+	if isHalf[T]() {
+		return castSparse[T](SparseSubtract(castSparse[float32](s1), castSparse[float32](s2)))
+	}
+
+	return mergeSparse(s1, s2, true, func(a, b T) T { return a - b })
+}
+
+// Multiplies two sparse tensors of the same shape elementwise. Only the elements stored in both are kept. The result
+// is in CSR format.
+func SparseMultiply[T Scalar](s1, s2 *SparseTensor[T]) *SparseTensor[T] {
+	if isHalf[T]() {
+		return castSparse[T](SparseMultiply(castSparse[float32](s1), castSparse[float32](s2)))
+	}
+
+	return mergeSparse(s1, s2, false, func(a, b T) T { return a * b })
+}
+
+// Multiplies a sparse tensor elementwise with a dense tensor that can be broadcast to its shape, like a row of scales.
+// The result keeps the sparsity of s & is in CSR format.
+func SparseMultiplyDense[T Scalar](s *SparseTensor[T], t *Tensor[T]) *SparseTensor[T] {
+	if isHalf[T]() {
+		return castSparse[T](SparseMultiplyDense(castSparse[float32](s), Cast[float32](t)))
+	}
+
+	// t must broadcast to the shape of s, not just be compatible with it, since the result has the shape of s
+	if shape, ok := broadcastShapes(s.shape, t.shape); !ok || !slices.Equal(shape, s.shape) {
+		panic(fmt.Sprintf("%s sparse %v & dense %v", ErrorCannotBroadcast, s.shape, t.shape))
+	}
+
+	b := &BroadcastTensor[T]{shape: s.shape, tensor: t}
+	csr := s.ToCSR()
+	result := sparseMap(csr, func(value T) T { return value })
+	for r := 0; r < int(s.shape[0]); r++ {
+		for i := csr.indptr[r]; i < csr.indptr[r+1]; i++ {
+			result.values[i] *= b.Get(r, csr.indices[i])
+		}
+	}
+
+	return result
+}
+
+// Merges the rows of two sparse tensors of the same shape with op. If union is true, elements stored in only one of
+// them are combined with zero, otherwise they are dropped.
+func mergeSparse[T Scalar](s1, s2 *SparseTensor[T], union bool, op func(a, b T) T) *SparseTensor[T] {
+	if !slices.Equal(s1.shape, s2.shape) {
+		panic(fmt.Sprintf("%s %v & %v", ErrorShapeMismatch, s1.shape, s2.shape))
+	}
+
+	a, b := s1.ToCSR(), s2.ToCSR()
+	result := &SparseTensor[T]{format: SparseCSR, shape: slices.Clone(s1.shape), indptr: make([]int, s1.shape[0]+1)}
+
+	add := func(col int, value T) {
+		result.indices = append(result.indices, col)
+		result.values = append(result.values, value)
+	}
+
+	for r := 0; r < int(s1.shape[0]); r++ {
+		i, iEnd := a.indptr[r], a.indptr[r+1]
+		j, jEnd := b.indptr[r], b.indptr[r+1]
+		for i < iEnd || j < jEnd {
+			switch {
+			case j >= jEnd || (i < iEnd && a.indices[i] < b.indices[j]):
+				if union {
+					add(a.indices[i], op(a.values[i], 0))
+				}
+				i++
+			case i >= iEnd || b.indices[j] < a.indices[i]:
+				if union {
+					add(b.indices[j], op(0, b.values[j]))
+				}
+				j++
+			default:
+				add(a.indices[i], op(a.values[i], b.values[j]))
+				i++
+				j++
+			}
+		}
+
+		result.indptr[r+1] = len(result.values)
+	}
+
+	return result
+}
+
+// Multiplies a sparse matrix with a dense matrix on the right. The result is dense. This is what a Dense layer's
+// forward pass needs for sparse inputs.
+func SparseMatMul[T Scalar](s *SparseTensor[T], t *Tensor[T]) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](SparseMatMul(castSparse[float32](s), Cast[float32](t)))
+	}
+
+	if t.NDims() != 2 {
+		panic("Both tensors must be 2D matrices!")
+	}
+
+	if s.shape[1] != t.shape[0] {
+		panic(ErrorMatMulConflictingDims)
+	}
+
+	csr := s.ToCSR()
+	numCols := int(t.shape[1])
+	result := WithShape[T]([]uint{s.shape[0], t.shape[1]})
+	for r := 0; r < int(s.shape[0]); r++ {
+		row := result.data[r*numCols : (r+1)*numCols]
+		for i := csr.indptr[r]; i < csr.indptr[r+1]; i++ {
+			k, value := csr.indices[i], csr.values[i]
+			for c := range row {
+				row[c] += value * t.UnsafeGet(k, c)
+			}
+		}
+	}
+
+	return result
+}
+
+// Multiplies a dense matrix with a sparse matrix on the right. The result is dense.
+func DenseSparseMatMul[T Scalar](t *Tensor[T], s *SparseTensor[T]) *Tensor[T] {
+	if isHalf[T]() {
+		return Cast[T](DenseSparseMatMul(Cast[float32](t), castSparse[float32](s)))
+	}
+
+	if t.NDims() != 2 {
+		panic("Both tensors must be 2D matrices!")
+	}
+
+	if t.shape[1] != s.shape[0] {
+		panic(ErrorMatMulConflictingDims)
+	}
+
+	csc := s.ToCSC()
+	numRows := int(t.shape[0])
+	numCols := int(s.shape[1])
+	result := WithShape[T]([]uint{t.shape[0], s.shape[1]})
+	for c := 0; c < numCols; c++ {
+		for i := csc.indptr[c]; i < csc.indptr[c+1]; i++ {
+			k, value := csc.indices[i], csc.values[i]
+			for r := 0; r < numRows; r++ {
+				result.data[r*numCols+c] += t.UnsafeGet(r, k) * value
+			}
+		}
+	}
+
+	return result
+}
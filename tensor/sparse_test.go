@@ -0,0 +1,124 @@
+package tensor
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+var denseMatrix = WithValue[int]([][]int{
+	{0, 2, 0, 0},
+	{1, 0, 0, 3},
+	{0, 0, 0, 0},
+	{0, 4, 5, 0},
+})
+
+func TestSparseConversions(t *testing.T) {
+	coo := NewCOO([]uint{4, 4}, []int{3, 1, 0, 1, 3, 1}, []int{1, 0, 1, 3, 2, 0}, []int{4, 2, 2, 3, 5, -1})
+	if coo.NNZ() != 5 {
+		t.Fatalf("NNZ(): expected 5 after summing duplicates, got %d", coo.NNZ())
+	}
+
+	if !reflect.DeepEqual(denseMatrix, coo.ToDense()) {
+		t.Fatalf("expected %v, got %v", denseMatrix, coo.ToDense())
+	}
+
+	csr := SparseFromDense(denseMatrix)
+	for _, s := range []*SparseTensor[int]{csr, csr.ToCSC(), csr.ToCSC().ToCOO(), csr.ToCSC().ToCSR()} {
+		if !reflect.DeepEqual(denseMatrix, s.ToDense()) {
+			t.Fatalf("%v: expected %v, got %v", s.Format(), denseMatrix, s.ToDense())
+		}
+	}
+
+	// every format finds every element in place
+	for _, s := range []*SparseTensor[int]{csr, csr.ToCSC(), coo} {
+		for r := 0; r < 4; r++ {
+			for c := 0; c < 4; c++ {
+				if value := s.At(r, c); value != denseMatrix.Get(r, c) {
+					t.Fatalf("%v: At(%d, %d): expected %d, got %d", s.Format(), r, c, denseMatrix.Get(r, c), value)
+				}
+			}
+		}
+	}
+}
+
+func TestSparseMatMul(t *testing.T) {
+	s := SparseFromDense(denseMatrix)
+	dense := WithValue[int]([][]int{{1, 2}, {3, 4}, {5, 6}, {7, 8}})
+
+	expected := MatrixMultiplication(denseMatrix, dense)
+	result := SparseMatMul(s, dense)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	expected = MatrixMultiplication(dense.Transpose(), denseMatrix)
+	result = DenseSparseMatMul(dense.Transpose(), s)
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestSparseElementwise(t *testing.T) {
+	s1 := SparseFromDense(denseMatrix)
+	s2 := SparseFromDense(WithValue[int]([][]int{
+		{1, 1, 0, 0},
+		{0, 0, 0, -3},
+		{0, 0, 0, 0},
+		{0, 0, 2, 0},
+	}))
+
+	expected := Add(denseMatrix, s2.ToDense())
+	result := SparseAdd(s1, s2)
+	if !reflect.DeepEqual(expected, result.ToDense()) {
+		t.Fatalf("expected %v, got %v", expected, result.ToDense())
+	}
+
+	expected = Multiply(denseMatrix, s2.ToDense())
+	result = SparseMultiply(s1, s2)
+	if !reflect.DeepEqual(expected, result.ToDense()) || result.NNZ() != 3 {
+		t.Fatalf("expected %v with 3 elements, got %v with %d", expected, result.ToDense(), result.NNZ())
+	}
+
+	scales := WithValue[int]([]int{1, 10, 100, 1000})
+	expected = Multiply(denseMatrix, scales)
+	result = SparseMultiplyDense(s1, scales)
+	if !reflect.DeepEqual(expected, result.ToDense()) || result.NNZ() != s1.NNZ() {
+		t.Fatalf("expected %v, got %v", expected, result.ToDense())
+	}
+
+	// a dense operand with more rows would be compatible, but not broadcast to the shape of the sparse one
+	defer func() {
+		if message, _ := recover().(string); !strings.HasPrefix(message, ErrorCannotBroadcast) {
+			t.Fatalf("expected a %q panic, got %q", ErrorCannotBroadcast, message)
+		}
+	}()
+
+	SparseMultiplyDense(s1.SliceRows(0, 1), WithShape[int]([]uint{2, 4}, 1))
+}
+
+func TestSparseSliceRows(t *testing.T) {
+	s := SparseFromDense(denseMatrix)
+
+	expected := WithValue[int]([][]int{{1, 0, 0, 3}, {0, 0, 0, 0}})
+	result := s.SliceRows(1, 3)
+	if !reflect.DeepEqual(expected, result.ToDense()) {
+		t.Fatalf("expected %v, got %v", expected, result.ToDense())
+	}
+}
+
+func TestSparseHalf(t *testing.T) {
+	dense := Cast[Float16](denseMatrix)
+	s := SparseFromDense(dense)
+
+	scaled := s.Scale(Float16FromFloat32(2))
+	expected := Cast[Float16](Multiply(denseMatrix, WithValue[int](2)))
+	if !reflect.DeepEqual(expected, scaled.ToDense()) {
+		t.Fatalf("Scale(): expected %v, got %v", expected, scaled.ToDense())
+	}
+
+	sum := SparseAdd(SparseFromDense(Cast[BFloat16](denseMatrix)), SparseFromDense(Cast[BFloat16](denseMatrix)))
+	if expected := Cast[BFloat16](Add(denseMatrix, denseMatrix)); !reflect.DeepEqual(expected, sum.ToDense()) {
+		t.Fatalf("SparseAdd(): expected %v, got %v", expected, sum.ToDense())
+	}
+}
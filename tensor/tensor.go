@@ -3,6 +3,7 @@ package tensor
 import (
 	"fmt"
 	"reflect"
+	"slices"
 )
 
 // Tensor is a struct that represents a multi-dimensional array.
@@ -13,11 +14,22 @@ type Tensor[T Scalar] struct {
 	strides  []uint
 }
 
-// Returns the value of the tensor.
+// Returns the value of the tensor, i.e. its elements in the order given by Strides(), which is row-major unless the
+// tensor was created in another order. Data() & ToNested() return them with their actual type.
 func (t *Tensor[T]) Value() interface{} {
 	return t.data
 }
 
+// Returns the elements of a row-major (C contiguous) tensor as a flat slice, without copying them, so changes to the
+// slice change the tensor. Panics for other layouts, which can be converted with AsC() first.
+func (t *Tensor[T]) Data() []T {
+	if !t.IsCContiguous() {
+		panic(fmt.Sprintf("Data(): expected a row-major tensor, got strides %v for shape %v", t.strides, t.shape))
+	}
+
+	return t.data
+}
+
 // Returns the shape of the tensor.
 func (t *Tensor[T]) Shape() []uint {
 	return t.shape
@@ -34,7 +46,7 @@ func (t *Tensor[T]) DataType() reflect.Type {
 }
 
 func (t *Tensor[T]) Copy() *Tensor[T] {
-	dataCopy := make([]T, len(t.data))
+	dataCopy := allocData[T](len(t.data))
 	copy(dataCopy, t.data)
 
 	return &Tensor[T]{
@@ -46,17 +58,13 @@ func (t *Tensor[T]) Copy() *Tensor[T] {
 }
 
 func (t *Tensor[T]) Reshape(newDims ...uint) *Tensor[T] {
-	if len(newDims) == 0 {
-		panic("Cannot reshape to an empty shape!")
-	}
-
 	// make sure that reshaping is possible
 	if countElementsFromShape(newDims) != countElementsFromShape(t.shape) {
 		panic(fmt.Sprintf("Incompatible reshaping: %v -> %v", t.shape, newDims))
 	}
 
-	// create a copy of this tensor
-	tCopy := t.Copy()
+	// create a copy of this tensor. the elements keep their row-major order, like in NumPy
+	tCopy := t.rowMajor().Copy()
 
 	// update the shape and strides
 	tCopy.shape = newDims
@@ -75,41 +83,134 @@ func (t *Tensor[T]) indicesToDataIndex(indices ...int) int {
 	return dataIndex
 }
 
-func (t *Tensor[T]) Get(indices ...int) T {
+// Like indicesToDataIndex(), but validates the indices first. Negative indices count from the end of their dimension,
+// like in Python.
+func (t *Tensor[T]) checkedDataIndex(indices []int) int {
 	if len(indices) != len(t.shape) {
 		panic(fmt.Sprintf("Invalid number of indices %d for tensor of shape %v", len(indices), t.shape))
 	}
 
-	index := t.indicesToDataIndex(indices...)
-	return t.data[index]
+	dataIndex := 0
+	for i, index := range indices {
+		dataIndex += normalizeIndex(index, i, t.shape) * int(t.strides[i])
+	}
+
+	return dataIndex
 }
 
+// Returns the non-negative equivalent of an index along a dimension of the shape, or panics if it's out of bounds.
+func normalizeIndex(index, dim int, shape []uint) int {
+	size := int(shape[dim])
+	if index < -size || index >= size {
+		panic(fmt.Sprintf("%s Index %d is out of bounds for dimension %d of size %d in shape %v", ErrorIndexOutOfBounds, index, dim, size, shape))
+	}
+
+	if index < 0 {
+		return index + size
+	}
+
+	return index
+}
+
+// Returns the element at the indices. Negative indices count from the end, e.g. t.Get(-1, 0) is the first element of
+// the last row of a matrix. Panics if an index is out of bounds.
+func (t *Tensor[T]) Get(indices ...int) T {
+	return t.data[t.checkedDataIndex(indices)]
+}
+
+// Sets the element at the indices, which are interpreted like in Get().
 func (t *Tensor[T]) Set(indices []int, value T) {
-	if len(indices) != len(t.shape) {
-		panic(fmt.Sprintf("Invalid number of indices %d for tensor of shape %v", len(indices), t.shape))
+	t.data[t.checkedDataIndex(indices)] = value
+}
+
+// Returns the element at the indices without validating them, for loops that already know they are in bounds. The
+// indices must be non-negative & there must be one per dimension, otherwise another element may be returned silently.
+func (t *Tensor[T]) UnsafeGet(indices ...int) T {
+	return t.data[t.indicesToDataIndex(indices...)]
+}
+
+// Sets the element at the indices without validating them, see UnsafeGet().
+func (t *Tensor[T]) UnsafeSet(indices []int, value T) {
+	t.data[t.indicesToDataIndex(indices...)] = value
+}
+
+// Returns the only element of a tensor with a single element, like a 0D tensor returned by reducing a 1D one.
+func (t *Tensor[T]) Item() T {
+	if len(t.data) != 1 {
+		panic(fmt.Sprintf("Item(): expected a tensor with a single element, got shape %v", t.shape))
 	}
 
-	index := t.indicesToDataIndex(indices...)
-	t.data[index] = value
+	return t.data[0]
+}
+
+// Returns a copy of the elements of a 1D tensor.
+func (t *Tensor[T]) ToSlice1D() []T {
+	t.ensureDims("ToSlice1D", 1)
+	return slices.Clone(t.rowMajor().data)
+}
+
+// Returns a copy of the elements of a 2D tensor as a slice of rows.
+func (t *Tensor[T]) ToSlice2D() [][]T {
+	t.ensureDims("ToSlice2D", 2)
+	return splitSlice(slices.Clone(t.rowMajor().data), int(t.shape[0]))
 }
 
-// Returns a string representation of the tensor.
-func (t *Tensor[T]) String() string {
-	if t.NDims() < 2 {
-		return fmt.Sprintf("Tensor(%v)", prettifyTensorValue(t.data))
+// Returns a copy of the elements of a 3D tensor as nested slices, indexed like Get().
+func (t *Tensor[T]) ToSlice3D() [][][]T {
+	t.ensureDims("ToSlice3D", 3)
+	return splitSlice(splitSlice(slices.Clone(t.rowMajor().data), int(t.shape[0]*t.shape[1])), int(t.shape[0]))
+}
+
+// Returns a copy of the elements as nested slices with one level per dimension, e.g. a [][][][]float64 for a 4D
+// tensor of float64, or a T for a 0D tensor. It's the inverse of WithValue().
+func (t *Tensor[T]) ToNested() interface{} {
+	if t.NDims() == 0 {
+		return t.rowMajor().data[0]
 	}
 
-	// when we've more than 1 dimensions, we first convert the flattened t.data to a multi-dimensional representation
-	// this makes it easier to work with the data while creating its string representation
+	return nestedValue(t.shape, slices.Clone(t.rowMajor().data)).Interface()
+}
 
-	tensorValue := initTensorValue[T](t.shape)
-	tensorIndices := getAllIndices(t.shape)
-	for i := 0; i < len(tensorIndices); i++ {
-		v := t.Get(tensorIndices[i]...)
-		valueAt(tensorValue, tensorIndices[i]...).Set(reflect.ValueOf(v))
+// Returns row-major data of the given shape as nested slices.
+func nestedValue[T Scalar](shape []uint, data []T) reflect.Value {
+	if len(shape) == 1 {
+		return reflect.ValueOf(data)
 	}
 
-	return fmt.Sprintf("Tensor(%v)", prettifyTensorValue(tensorValue))
+	var zero T
+	sliceType := reflect.TypeOf(zero)
+	for range shape {
+		sliceType = reflect.SliceOf(sliceType)
+	}
+
+	n := int(shape[0])
+	nested := reflect.MakeSlice(sliceType, n, n)
+	for i, part := range splitSlice(data, n) {
+		nested.Index(i).Set(nestedValue(shape[1:], part))
+	}
+
+	return nested
+}
+
+// Splits a slice into n consecutive parts of the same length. Appending to a part doesn't overwrite the next one.
+func splitSlice[E any](s []E, n int) [][]E {
+	parts := make([][]E, n)
+	size := 0
+	if n > 0 {
+		size = len(s) / n
+	}
+
+	for i := range parts {
+		parts[i] = s[i*size : (i+1)*size : (i+1)*size]
+	}
+
+	return parts
+}
+
+func (t *Tensor[T]) ensureDims(function string, numDims int) {
+	if t.NDims() != numDims {
+		panic(fmt.Sprintf("%s(): expected a %dD tensor, got shape %v", function, numDims, t.shape))
+	}
 }
 
 // Adds two tensors.
@@ -143,13 +244,7 @@ func WithShape[T Scalar](shape []uint, initialValue ...T) *Tensor[T] {
 		panic("Only one initial value is allowed!")
 	}
 
-	for i, dim := range shape {
-		if dim <= 0 {
-			panic(fmt.Sprintf("Invalid shape: dimension %d cannot be %d", i, dim))
-		}
-	}
-
-	data := make([]T, countElementsFromShape(shape))
+	data := allocData[T](int(countElementsFromShape(shape)))
 	if len(initialValue) > 0 {
 		for i := 0; i < len(data); i++ {
 			data[i] = initialValue[0]
@@ -168,13 +263,7 @@ func WithShape[T Scalar](shape []uint, initialValue ...T) *Tensor[T] {
 }
 
 func WithRandom[T Scalar](shape []uint, minValue, maxValue T) *Tensor[T] {
-	for i, dim := range shape {
-		if dim <= 0 {
-			panic(fmt.Sprintf("Invalid shape: dimension %d cannot be %d", i, dim))
-		}
-	}
-
-	data := make([]T, countElementsFromShape(shape))
+	data := allocData[T](int(countElementsFromShape(shape)))
 	for i := 0; i < len(data); i++ {
 		data[i] = randomBetween(minValue, maxValue)
 	}
@@ -190,6 +279,23 @@ func WithRandom[T Scalar](shape []uint, minValue, maxValue T) *Tensor[T] {
 	}
 }
 
+// Creates a new tensor of the given shape that uses data as its (row-major) storage.
+func fromData[T Scalar](shape []uint, data []T) *Tensor[T] {
+	if uint(len(data)) != countElementsFromShape(shape) {
+		panic(fmt.Sprintf("Cannot create a tensor of shape %v from %d elements", shape, len(data)))
+	}
+
+	// dummy variable to get the data type at runtime
+	var dataType T
+
+	return &Tensor[T]{
+		data:     data,
+		dataType: reflect.TypeOf(dataType),
+		shape:    shape,
+		strides:  calculateStrides(shape),
+	}
+}
+
 // Create a new tensor from the given value.
 func WithValue[T Scalar](data interface{}) *Tensor[T] {
 	// validate that the tensor is homogenous, i.e., all elements are of the same type
@@ -206,7 +312,7 @@ func WithValue[T Scalar](data interface{}) *Tensor[T] {
 	// its length would be same as the number of elements in the tensor
 	tensorIndices := getAllIndices(shape)
 
-	tensorData := make([]T, numElements)
+	tensorData := allocData[T](int(numElements))
 	for i := uint(0); i < numElements; i++ {
 		tensorData[i] = valueAt(data, tensorIndices[i]...).Interface().(T)
 	}
@@ -0,0 +1,239 @@
+package tensor
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestItem(t *testing.T) {
+	scalar := WithValue[float64](3.5)
+	if scalar.NDims() != 0 || scalar.Item() != 3.5 || scalar.Get() != 3.5 {
+		t.Fatalf("expected a 0D tensor holding 3.5, got %v", scalar)
+	}
+
+	sum := Sum(WithValue[int]([]int{1, 2, 3}), 0)
+	if sum.NDims() != 0 || sum.Item() != 6 {
+		t.Fatalf("Sum(): expected a 0D tensor holding 6, got %v", sum)
+	}
+
+	if reshaped := WithValue[int]([][]int{{7}}).Reshape(); reshaped.NDims() != 0 || reshaped.Item() != 7 {
+		t.Fatalf("Reshape(): expected a 0D tensor holding 7, got %v", reshaped)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Item(): expected a panic for a tensor with 2 elements")
+		}
+	}()
+
+	WithValue[int]([]int{1, 2}).Item()
+}
+
+func TestZeroSizeDimensions(t *testing.T) {
+	shapes := []struct {
+		value    interface{}
+		expected []uint
+	}{
+		{[]float64{}, []uint{0}},
+		{[][]float64{}, []uint{0, 0}},
+		{[][3]float64{}, []uint{0, 3}},
+		{[][]float64{{}, {}}, []uint{2, 0}},
+	}
+
+	for _, test := range shapes {
+		if shape := WithValue[float64](test.value).Shape(); !reflect.DeepEqual(test.expected, shape) {
+			t.Fatalf("WithValue(%#v): expected shape %v, got %v", test.value, test.expected, shape)
+		}
+	}
+
+	batch := WithShape[float64]([]uint{0, 3})
+	bias := WithValue[float64]([]float64{1, 2, 3})
+
+	check := func(name string, expected []uint, got *Tensor[float64]) {
+		t.Helper()
+		if !reflect.DeepEqual(expected, got.Shape()) {
+			t.Fatalf("%s: expected shape %v, got %v", name, expected, got.Shape())
+		}
+	}
+
+	check("Add", []uint{0, 3}, Add(batch, bias))
+	check("Add of a column", []uint{0, 3}, Add(batch, WithShape[float64]([]uint{0, 1})))
+	check("MatrixMultiplication", []uint{0, 4}, MatrixMultiplication(batch, WithShape[float64]([]uint{3, 4})))
+	check("Transpose", []uint{3, 0}, Transpose(batch))
+	check("Reshape", []uint{3, 0, 5}, batch.Reshape(3, 0, 5))
+	check("Sort", []uint{0, 3}, Sort(batch, 0))
+	check("CumSum", []uint{0, 3}, CumSum(batch, 0))
+	check("ApplyAlongAxis", []uint{0, 3}, ApplyAlongAxis(batch, 1, func(lane []float64) []float64 { return lane }))
+	check("Einsum", []uint{0, 4}, Einsum("ij,jk->ik", batch, WithShape[float64]([]uint{3, 4})))
+	check("Einsum with a broadcast ellipsis", []uint{0}, Einsum("...i,...i->...", batch, WithShape[float64]([]uint{1, 3})))
+
+	input := WithShape[float64]([]uint{0, 2, 5, 5})
+	weight := WithRandom[float64]([]uint{3, 2, 3, 3}, -1, 1)
+	check("Conv", []uint{0, 3, 3, 3}, Conv(input, weight, ConvOptions{}))
+
+	// the inner dimension of 0 gives sums of no products
+	product := MatrixMultiplication(WithShape[float64]([]uint{2, 0}), WithShape[float64]([]uint{0, 3}))
+	if expected := WithShape[float64]([]uint{2, 3}); !reflect.DeepEqual(expected, product) {
+		t.Fatalf("MatrixMultiplication(): expected %v, got %v", expected, product)
+	}
+
+	if product := Einsum("ij,jk->ik", WithShape[float64]([]uint{2, 0}), WithShape[float64]([]uint{0, 3})); !reflect.DeepEqual(WithShape[float64]([]uint{2, 3}), product) {
+		t.Fatalf("Einsum(): expected 2x3 zeros, got %v", product)
+	}
+
+	// reductions of empty axes give the identities
+	if sum := Sum(batch, 0); !reflect.DeepEqual(WithValue[float64]([]float64{0, 0, 0}), sum) {
+		t.Fatalf("Sum(): expected zeros, got %v", sum)
+	}
+
+	if prod := Prod(batch, 0); !reflect.DeepEqual(WithValue[float64]([]float64{1, 1, 1}), prod) {
+		t.Fatalf("Prod(): expected ones, got %v", prod)
+	}
+
+	check("Sum of a non-empty axis", []uint{0}, Sum(batch, 1))
+
+	// empty tensors round-trip through printing & the encodings
+	for _, tensor := range []*Tensor[float64]{batch, WithShape[float64]([]uint{2, 0})} {
+		parsed, err := Parse[float64](fmt.Sprintf("%+v", tensor))
+		if err != nil || !reflect.DeepEqual(tensor, parsed) {
+			t.Fatalf("Parse(): expected %+v, got %+v (%v)", tensor, parsed, err)
+		}
+
+		encoded, err := tensor.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		decoded := &Tensor[float64]{}
+		if err := decoded.UnmarshalBinary(encoded); err != nil || !reflect.DeepEqual(tensor.Shape(), decoded.Shape()) {
+			t.Fatalf("UnmarshalBinary(): expected shape %v, got %v (%v)", tensor.Shape(), decoded.Shape(), err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Max(): expected a panic for an empty axis")
+		}
+	}()
+
+	Max(batch, 0)
+}
+
+func TestData(t *testing.T) {
+	tensor := WithValue[float32]([][]float32{{1, 2, 3}, {4, 5, 6}})
+
+	data := tensor.Data()
+	if !reflect.DeepEqual([]float32{1, 2, 3, 4, 5, 6}, data) {
+		t.Fatalf("Data(): expected the row-major elements, got %v", data)
+	}
+
+	// the data isn't copied
+	data[0] = 10
+	if tensor.Get(0, 0) != 10 {
+		t.Fatal("Data(): expected the slice to share the tensor's elements")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Data(): expected a panic for a column-major tensor")
+		}
+	}()
+
+	tensor.AsFortran().Data()
+}
+
+func TestToSlice(t *testing.T) {
+	values := [][][]int{{{1, 2}, {3, 4}, {5, 6}}, {{7, 8}, {9, 10}, {11, 12}}}
+	tensor := WithValue[int](values)
+
+	if slice := tensor.ToSlice3D(); !reflect.DeepEqual(values, slice) {
+		t.Fatalf("ToSlice3D(): expected %v, got %v", values, slice)
+	}
+
+	// the layout doesn't matter
+	if nested := tensor.AsFortran().ToNested(); !reflect.DeepEqual(values, nested) {
+		t.Fatalf("ToNested(): expected %v, got %v", values, nested)
+	}
+
+	matrix := tensor.Reshape(3, 4)
+	rows := matrix.ToSlice2D()
+	expectedRows := [][]int{{1, 2, 3, 4}, {5, 6, 7, 8}, {9, 10, 11, 12}}
+	if !reflect.DeepEqual(expectedRows, rows) {
+		t.Fatalf("ToSlice2D(): expected %v, got %v", expectedRows, rows)
+	}
+
+	// the rows are copies that can grow independently
+	rows[0] = append(rows[0], 100)
+	if rows[1][0] != 5 || matrix.Get(1, 0) != 5 {
+		t.Fatal("ToSlice2D(): appending to a row overwrote the next one")
+	}
+
+	if slice := tensor.Reshape(12).ToSlice1D(); len(slice) != 12 || slice[11] != 12 {
+		t.Fatalf("ToSlice1D(): expected 12 elements ending with 12, got %v", slice)
+	}
+
+	if nested := WithValue[int](5).ToNested(); nested != 5 {
+		t.Fatalf("ToNested(): expected 5 for a 0D tensor, got %v", nested)
+	}
+
+	if nested := WithShape[int]([]uint{2, 0}).ToNested(); !reflect.DeepEqual([][]int{{}, {}}, nested) {
+		t.Fatalf("ToNested(): expected 2 empty rows, got %#v", nested)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("ToSlice2D(): expected a panic for a 3D tensor")
+		}
+	}()
+
+	tensor.ToSlice2D()
+}
+
+func TestGetSetBounds(t *testing.T) {
+	tensor := WithValue[int]([][]int{{1, 2, 3}, {4, 5, 6}})
+
+	if tensor.Get(-1, 0) != 4 || tensor.Get(0, -1) != 3 || tensor.Get(-2, -3) != 1 {
+		t.Fatal("Get(): negative indices should count from the end")
+	}
+
+	tensor.Set([]int{-1, -1}, 60)
+	if tensor.UnsafeGet(1, 2) != 60 {
+		t.Fatalf("Set(): expected 60 at [1 2], got %d", tensor.UnsafeGet(1, 2))
+	}
+
+	tensor.UnsafeSet([]int{0, 1}, 20)
+	if tensor.Get(0, 1) != 20 {
+		t.Fatalf("UnsafeSet(): expected 20 at [0 1], got %d", tensor.Get(0, 1))
+	}
+
+	if value := Broadcast(tensor, WithShape[int]([]uint{4, 1, 1}))[0].Get(-1, -1, 0); value != 4 {
+		t.Fatalf("BroadcastTensor.Get(): expected 4, got %d", value)
+	}
+
+	// the leading indices of a broadcast are checked too, although the tensor has no such dimension
+	func() {
+		defer func() {
+			if message, _ := recover().(string); !strings.HasPrefix(message, ErrorIndexOutOfBounds) {
+				t.Fatalf("BroadcastTensor.Get(): expected an out of bounds panic, got %q", message)
+			}
+		}()
+
+		Broadcast(WithValue[int]([]int{1, 2}), WithShape[int]([]uint{3, 2}))[0].Get(100, 1)
+	}()
+
+	// before, [0 3] silently read the element at [1 0]
+	for _, indices := range [][]int{{0, 3}, {2, 0}, {-3, 0}, {0, -4}} {
+		func() {
+			defer func() {
+				message, _ := recover().(string)
+				if !strings.HasPrefix(message, ErrorIndexOutOfBounds) {
+					t.Fatalf("Get(%v): expected an out of bounds panic, got %q", indices, message)
+				}
+			}()
+
+			tensor.Get(indices...)
+		}()
+	}
+}
@@ -1,10 +1,12 @@
 package tensor
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/rand/v2"
 	"reflect"
-	"strings"
+	"slices"
 )
 
 // Recursively initializes the tensor with the given shape and initial value.
@@ -57,9 +59,10 @@ func ensureHomogeneous[T Scalar](value interface{}) {
 		panic(ErrorNonArraySlice)
 	}
 
-	// it should not be empty
+	// an empty value has no elements to validate, so its type must be right
 	if val.Len() == 0 {
-		panic(ErrorEmptyArraySlice)
+		ensureElementType[T](val.Type())
+		return
 	}
 
 	// validate each element's type
@@ -79,6 +82,19 @@ func ensureHomogeneous[T Scalar](value interface{}) {
 	}
 }
 
+// Validates that the innermost element type of a (nested) array or slice type is T, or an interface that could hold
+// a T.
+func ensureElementType[T Scalar](valueType reflect.Type) {
+	for valueType.Kind() == reflect.Array || valueType.Kind() == reflect.Slice {
+		valueType = valueType.Elem()
+	}
+
+	var validScalar T
+	if valueType.Kind() != reflect.Interface && valueType != reflect.TypeOf(validScalar) {
+		panic(fmt.Sprintf("Unexpected type %v in tensor. Expected a Scalar of type %T", valueType, validScalar))
+	}
+}
+
 // Checks if the provided tensor value is homologous. Panics with an error message if it's not.
 // It first detects and ensure that the tensor matches the shape. Basically, it's a wrapper over detectShape() and ensureShape() functions.
 func ensureHomologous(value interface{}) {
@@ -94,7 +110,7 @@ func ensureHomologous(value interface{}) {
 
 // Tries to detect the shape of the tensor value.
 func detectShape(value interface{}) (shape []uint) {
-	val := reflect.ValueOf(value)
+	val := unwrapInterface(reflect.ValueOf(value))
 	shape = []uint{}
 
 	for {
@@ -104,113 +120,96 @@ func detectShape(value interface{}) (shape []uint) {
 			break
 		}
 
-		// validate that it's not empty
-		if val.Len() == 0 {
-			panic(ErrorEmptyArraySlice)
-		}
-
 		// append the size of the current dimension
 		shape = append(shape, uint(val.Len()))
 
+		// an empty value has no element to look into, so the remaining dimensions are taken from its type: the
+		// length of arrays, and 0 for slices
+		if val.Len() == 0 {
+			for elemType := val.Type().Elem(); elemType.Kind() == reflect.Array || elemType.Kind() == reflect.Slice; elemType = elemType.Elem() {
+				if elemType.Kind() == reflect.Array {
+					shape = append(shape, uint(elemType.Len()))
+				} else {
+					shape = append(shape, 0)
+				}
+			}
+
+			break
+		}
+
 		// go deeper to get next dimension's size
-		val = val.Index(0)
+		val = unwrapInterface(val.Index(0))
 	}
 
 	return shape
 }
 
+// Validates that the tensor value matches the given shape. Panics with an error message if it doesn't.
 func ensureShape(value interface{}, shape []uint, currentDim int) {
+	if err := checkShape(value, shape, currentDim); err != nil {
+		panic(err.Error())
+	}
+}
+
+// Checks if the tensor value matches the given shape from the current dimension onwards, and returns an error
+// describing the first mismatch if it doesn't.
+func checkShape(value interface{}, shape []uint, currentDim int) error {
 	if len(shape) == 0 {
-		panic("Invalid shape")
+		return errors.New("Invalid shape")
 	}
 
-	val := reflect.ValueOf(value)
+	val := unwrapInterface(reflect.ValueOf(value))
 	if val.Kind() != reflect.Array && val.Kind() != reflect.Slice {
-		panic(ErrorNonArraySlice)
+		return errors.New(ErrorNonArraySlice)
+	}
+
+	if currentDim >= len(shape) {
+		return fmt.Errorf("%s Detected shape was %v, but found an unexpected slice or array at dimension %d.",
+			ErrorNonHomologous,
+			shape,
+			currentDim,
+		)
 	}
 
 	if uint(val.Len()) != shape[currentDim] {
-		panic(fmt.Sprintf("%s Detected shape was %v, but there's a mismatch at dimension %d with size %d. Shouldn't it be of size %d?",
+		return fmt.Errorf("%s Detected shape was %v, but there's a mismatch at dimension %d with size %d. Shouldn't it be of size %d?",
 			ErrorNonHomologous,
 			shape,
 			currentDim,
 			val.Len(),
 			shape[currentDim],
-		))
+		)
 	}
 
 	for i := 0; i < val.Len(); i++ {
-		elem := val.Index(i)
+		elem := unwrapInterface(val.Index(i))
 
 		if elem.Kind() == reflect.Array || elem.Kind() == reflect.Slice {
-			ensureShape(elem.Interface(), shape, currentDim+1)
+			if err := checkShape(elem.Interface(), shape, currentDim+1); err != nil {
+				return err
+			}
+
 			continue
 		} else if currentDim < len(shape)-1 {
-			panic(fmt.Sprintf("%s Detected shape was %v, but found an unexpected %d at dimension %d. Expected a slice or array.",
+			return fmt.Errorf("%s Detected shape was %v, but found an unexpected %v at dimension %d. Expected a slice or array.",
 				ErrorNonHomologous,
 				shape,
 				elem.Type(),
 				currentDim,
-			))
+			)
 		}
 	}
-}
-
-// Returns a pretty string representation of the tensor value.
-func prettifyTensorValue(value interface{}, indentation ...int) string {
-	if IsScalar[any](value) {
-		return fmt.Sprintf("%v", value)
-	}
-
-	val := reflect.ValueOf(value)
-	if val.Kind() != reflect.Array && val.Kind() != reflect.Slice {
-		panic(ErrorNonArraySlice)
-	}
 
-	if len(indentation) == 0 {
-		indentation = []int{0}
-	}
-
-	indentationLevel := indentation[0]
-	indentationStr := strings.Repeat(" ", indentationLevel)
-	if val.Len() == 0 {
-		return indentationStr + "[]"
-	}
-
-	isDeepest := true
-	s := indentationStr + "["
-	for i := 0; i < val.Len(); i++ {
-		elem := val.Index(i)
-
-		if elem.Kind() == reflect.Interface {
-			elem = elem.Elem()
-		}
-
-		if elem.Kind() == reflect.Array || elem.Kind() == reflect.Slice {
-			s += "\n" + prettifyTensorValue(elem.Interface(), indentationLevel+2)
-			isDeepest = false
-		} else {
-			s += fmt.Sprintf("%v", elem.Interface())
-		}
-
-		// add comma if it's not the last element
-		if i < val.Len()-1 {
-			s += " "
-		}
-
-		// add new line if it's an array or slice
-		if (elem.Kind() == reflect.Array || elem.Kind() == reflect.Slice) && i == val.Len()-1 {
-			s += "\n"
-		}
-	}
+	return nil
+}
 
-	/// only add indentation before closing bracket if it's not the deepest level
-	if !isDeepest {
-		s += indentationStr
+// Returns the value stored in an interface, like the elements of a []interface{}. Other values are returned as is.
+func unwrapInterface(val reflect.Value) reflect.Value {
+	for val.Kind() == reflect.Interface && !val.IsNil() {
+		val = val.Elem()
 	}
 
-	s += "]"
-	return s
+	return val
 }
 
 func calculateStrides(shape []uint) []uint {
@@ -314,7 +313,33 @@ func countElementsFromShape(shape []uint) uint {
 	return count
 }
 
+// Like countElementsFromShape(), for shapes read from untrusted data: returns an error instead of wrapping around if
+// the number of elements, or their size in bytes, doesn't fit in an int.
+func checkedElementCount(shape []uint, elementSize int) (int, error) {
+	// a shape with a zero dimension is empty however large the others are
+	if slices.Contains(shape, 0) {
+		return 0, nil
+	}
+
+	count, limit := uint(1), uint(math.MaxInt/elementSize)
+	for _, dimSize := range shape {
+		if count > limit/dimSize {
+			return 0, fmt.Errorf("Shape %v has too many elements of %d bytes", shape, elementSize)
+		}
+
+		count *= dimSize
+	}
+
+	return int(count), nil
+}
+
 func areShapesBroadcastable(shapes ...[]uint) bool {
+	_, ok := broadcastShapes(shapes...)
+	return ok
+}
+
+// Returns the shape that all the shapes broadcast to, and false if they don't broadcast together.
+func broadcastShapes(shapes ...[]uint) ([]uint, bool) {
 	if len(shapes) == 0 {
 		panic("areShapesBroadcastable: At least one shape is required")
 	}
@@ -326,8 +351,10 @@ func areShapesBroadcastable(shapes ...[]uint) bool {
 		}
 	}
 
-	firstShape := make([]uint, maxDimensions)
-	copyWithPadding(firstShape, shapes[0], 1)
+	// every shape is checked against the broadcast of the previous ones, not just the first one, since e.g. [1], [2] &
+	// [3] are pairwise compatible with [1] but not with each other
+	broadcastShape := make([]uint, maxDimensions)
+	copyWithPadding(broadcastShape, shapes[0], 1)
 
 	currentShape := make([]uint, maxDimensions)
 	for i := 1; i < len(shapes); i++ {
@@ -336,15 +363,19 @@ func areShapesBroadcastable(shapes ...[]uint) bool {
 		for j := 0; j < maxDimensions; j++ {
 			// general broadcasting rules: https://numpy.org/doc/stable/user/basics.broadcasting.html#general-broadcasting-rules
 			// two dimensions are compatible if 1. they are equal, or 2. one of them is 1
-			f := firstShape[j]
+			b := broadcastShape[j]
 			c := currentShape[j]
-			if f != c && f != 1 && c != 1 {
-				return false
+			if b != c && b != 1 && c != 1 {
+				return nil, false
+			}
+
+			if b == 1 {
+				broadcastShape[j] = c
 			}
 		}
 	}
 
-	return true
+	return broadcastShape, true
 }
 
 func copyWithPadding[T Scalar](dest []T, src []T, padWith T) {
@@ -371,7 +402,66 @@ func randomBetween[T Scalar](minValue, maxValue T) T {
 		return T(rand.Float32()*float32(maxValue-minValue) + float32(minValue))
 	case float64:
 		return T(rand.Float64()*float64(maxValue-minValue) + float64(minValue))
+	case Float16, BFloat16:
+		low, high := toFloat64(minValue), toFloat64(maxValue)
+		return fromFloat64[T](rand.Float64()*(high-low) + low)
 	default:
 		panic("Unsupported type for random number generation")
 	}
 }
+
+// Converts a possibly negative axis (counting from the end) to a non-negative one. Panics if it's out of range.
+func normalizeAxis(axis, numDimensions int) int {
+	if axis < -numDimensions || axis >= numDimensions {
+		panic(fmt.Sprintf("Axis %d is out of bounds for a tensor with %d dimensions", axis, numDimensions))
+	}
+
+	if axis < 0 {
+		axis += numDimensions
+	}
+
+	return axis
+}
+
+// Returns a copy of the shape without the given axis.
+func removeAxis(shape []uint, axis int) []uint {
+	newShape := make([]uint, 0, len(shape)-1)
+	newShape = append(newShape, shape[:axis]...)
+	return append(newShape, shape[axis+1:]...)
+}
+
+// Returns the data index of the first element of every 1D lane along the axis, i.e. the elements whose index along
+// the axis is 0. The lanes are ordered in row-major order of the remaining dimensions. The element i of a lane is at
+// start + i*strides[axis].
+func laneStarts(shape, strides []uint, axis int) []int {
+	numLanes := 1
+	for i, dim := range shape {
+		if i != axis {
+			numLanes *= int(dim)
+		}
+	}
+
+	starts := make([]int, numLanes)
+	indices := make([]int, len(shape))
+	for lane := range starts {
+		for i, index := range indices {
+			starts[lane] += index * int(strides[i])
+		}
+
+		// increment the indices of every dimension but the axis
+		for dim := len(shape) - 1; dim >= 0; dim-- {
+			if dim == axis {
+				continue
+			}
+
+			indices[dim]++
+			if indices[dim] < int(shape[dim]) {
+				break
+			}
+
+			indices[dim] = 0
+		}
+	}
+
+	return starts
+}
@@ -44,3 +44,14 @@ func TestAreShapesBroadcastableNo(t *testing.T) {
 		t.Fatalf("areBroadcastable(): expected %v, got %v", expected, areBroadcastable)
 	}
 }
+
+func TestAreShapesBroadcastableAgainstEachOther(t *testing.T) {
+	// each of them is compatible with the first one, but not with the others
+	if areShapesBroadcastable([]uint{1}, []uint{2}, []uint{3}) {
+		t.Fatal("areShapesBroadcastable(): expected false for [1], [2] & [3]")
+	}
+
+	if shape, ok := broadcastShapes([]uint{1, 3}, []uint{2, 1}, []uint{3}); !ok || !reflect.DeepEqual([]uint{2, 3}, shape) {
+		t.Fatalf("broadcastShapes(): expected [2 3], got %v (%v)", shape, ok)
+	}
+}
@@ -0,0 +1,141 @@
+// Package tensortest provides assertions for tests that compare tensors.
+package tensortest
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/biraj21/nnfs-go/tensor"
+)
+
+// Maximum number of mismatching elements listed in a failure message.
+const maxReportedMismatches = 5
+
+// Reports a test error if got & want don't have the same shape or if any of their elements are not close within the
+// tolerance (tensor.DefaultTolerance by default). The message includes the first mismatching indices & the maximum
+// absolute and relative errors. Returns whether the assertion passed.
+func AssertAllClose[T tensor.Scalar](t testing.TB, got, want *tensor.Tensor[T], tolerance ...tensor.Tolerance) bool {
+	t.Helper()
+
+	if len(tolerance) > 1 {
+		panic("Only one tolerance is allowed!")
+	}
+
+	tol := tensor.DefaultTolerance
+	if len(tolerance) > 0 {
+		tol = tolerance[0]
+	}
+
+	if !reflect.DeepEqual(got.Shape(), want.Shape()) {
+		t.Errorf("tensors have different shapes: got %v, want %v", got.Shape(), want.Shape())
+		return false
+	}
+
+	isClose := tensor.IsClose(got, want, tol).Data()
+	matches := func(i int) bool { return isClose[i] != 0 }
+	if report := mismatchReport(got.Shape(), rowMajorData(got), rowMajorData(want), matches); report != "" {
+		t.Errorf("tensors are not close (rtol=%g, atol=%g):\n%s", tol.RTol, tol.ATol, report)
+		return false
+	}
+
+	return true
+}
+
+// Reports a test error if got & want don't have the same shape & elements. Returns whether the assertion passed.
+func AssertEqual[T tensor.Scalar](t testing.TB, got, want *tensor.Tensor[T]) bool {
+	t.Helper()
+
+	if !reflect.DeepEqual(got.Shape(), want.Shape()) {
+		t.Errorf("tensors have different shapes: got %v, want %v", got.Shape(), want.Shape())
+		return false
+	}
+
+	if tensor.Equal(got, want) {
+		return true
+	}
+
+	// compared exactly rather than through float64, which can't tell large 64-bit integers apart, except for the
+	// half-precision types whose bits differ for 0 & -0
+	gotData, wantData := rowMajorData(got), rowMajorData(want)
+	matches := func(i int) bool { return gotData[i] == wantData[i] }
+	switch any(*new(T)).(type) {
+	case tensor.Float16, tensor.BFloat16:
+		matches = func(i int) bool { return tensor.ToFloat64(gotData[i]) == tensor.ToFloat64(wantData[i]) }
+	}
+
+	if report := mismatchReport(got.Shape(), gotData, wantData, matches); report != "" {
+		t.Errorf("tensors are not equal:\n%s", report)
+		return false
+	}
+
+	return true
+}
+
+// Returns the elements of the tensor in row-major order, copying them only if it's stored in another order.
+func rowMajorData[T tensor.Scalar](t *tensor.Tensor[T]) []T {
+	if t.IsCContiguous() {
+		return t.Data()
+	}
+
+	return t.AsC().Data()
+}
+
+// Describes the elements of the row-major data of got & want, of the given shape, that don't match. matches tells
+// whether the elements at an index of the data do. Returns an empty string if there are none.
+func mismatchReport[T tensor.Scalar](shape []uint, gotData, wantData []T, matches func(i int) bool) string {
+	numElements := len(gotData)
+
+	var sb strings.Builder
+	numMismatches := 0
+	maxAbsError, maxRelError := 0.0, 0.0
+
+	indices := make([]int, len(shape))
+	for i := 0; i < numElements; i++ {
+		if !matches(i) {
+			g, w := gotData[i], wantData[i]
+			if numMismatches < maxReportedMismatches {
+				fmt.Fprintf(&sb, "    %v: got %v, want %v\n", indices, g, w)
+			}
+
+			numMismatches++
+
+			gf, wf := tensor.ToFloat64(g), tensor.ToFloat64(w)
+			absError := math.Abs(gf - wf)
+			maxAbsError = max(maxAbsError, absError)
+			if wf != 0 {
+				maxRelError = max(maxRelError, absError/math.Abs(wf))
+			}
+		}
+
+		// move on to the next indices in row-major order
+		for dim := len(shape) - 1; dim >= 0; dim-- {
+			indices[dim]++
+			if indices[dim] < int(shape[dim]) {
+				break
+			}
+
+			indices[dim] = 0
+		}
+	}
+
+	if numMismatches == 0 {
+		return ""
+	}
+
+	if numMismatches > maxReportedMismatches {
+		fmt.Fprintf(&sb, "    ... and %d more\n", numMismatches-maxReportedMismatches)
+	}
+
+	return fmt.Sprintf("  shape: %v\n  mismatched elements: %d / %d (%.1f%%)\n  first mismatches:\n%s  max absolute error: %g\n  max relative error: %g",
+		shape,
+		numMismatches,
+		numElements,
+		100*float64(numMismatches)/float64(numElements),
+		sb.String(),
+		maxAbsError,
+		maxRelError,
+	)
+}
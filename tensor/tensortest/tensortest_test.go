@@ -0,0 +1,82 @@
+package tensortest
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/biraj21/nnfs-go/tensor"
+)
+
+// Records the errors reported by the assertions instead of failing the test.
+type recorder struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertAllClose(t *testing.T) {
+	got := tensor.WithValue[float64]([][]float64{{1, 2}, {3, 4.5}})
+	want := tensor.WithValue[float64]([][]float64{{1, 2}, {3 + 1e-12, 4}})
+
+	r := &recorder{TB: t}
+	if AssertAllClose(r, got, want) {
+		t.Fatalf("AssertAllClose(): expected the assertion to fail")
+	}
+
+	if len(r.errors) != 1 {
+		t.Fatalf("expected 1 error, got %d", len(r.errors))
+	}
+
+	for _, expected := range []string{"[1 1]: got 4.5, want 4", "mismatched elements: 1 / 4", "max absolute error: 0.5", "max relative error: 0.125"} {
+		if !strings.Contains(r.errors[0], expected) {
+			t.Fatalf("expected the error to contain %q, got:\n%s", expected, r.errors[0])
+		}
+	}
+
+	AssertAllClose(t, got, got.Copy())
+}
+
+func TestAssertEqual(t *testing.T) {
+	r := &recorder{TB: t}
+	if AssertEqual(r, tensor.WithValue[int]([]int{1, 2}), tensor.WithValue[int]([][]int{{1, 2}})) {
+		t.Fatalf("AssertEqual(): expected the assertion to fail")
+	}
+
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "different shapes") {
+		t.Fatalf("expected a shape mismatch error, got %v", r.errors)
+	}
+
+	AssertEqual(t, tensor.WithValue[int]([]int{1, 2}), tensor.WithValue[int]([]int{1, 2}))
+
+	// float64 can't tell these apart
+	r = &recorder{TB: t}
+	if AssertEqual(r, tensor.WithValue[int64]([]int64{1, 1 << 60}), tensor.WithValue[int64]([]int64{1, 1<<60 + 1})) {
+		t.Fatalf("AssertEqual(): expected the assertion to fail for integers above 2^53")
+	}
+
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "mismatched elements: 1 / 2") {
+		t.Fatalf("expected a single mismatch, got %v", r.errors)
+	}
+
+	AssertEqual(t, tensor.WithValue[tensor.Float16](tensor.Float16FromFloat32(1.5)), tensor.WithValue[tensor.Float16](tensor.Float16FromFloat32(1.5)))
+
+	// half-precision values are compared by value, so -0 equals 0 despite its different bits
+	AssertEqual(t, tensor.WithValue[tensor.Float16](tensor.Float16FromFloat32(float32(math.Copysign(0, -1)))), tensor.WithValue[tensor.Float16](tensor.Float16(0)))
+
+	// the mismatching indices are those of the elements, whatever the memory layout
+	r = &recorder{TB: t}
+	if AssertEqual(r, tensor.WithValue[int]([][]int{{1, 2}, {3, 4}}).Transpose(), tensor.WithValue[int]([][]int{{1, 3}, {2, 5}})) {
+		t.Fatalf("AssertEqual(): expected the assertion to fail")
+	}
+
+	if len(r.errors) != 1 || !strings.Contains(r.errors[0], "[1 1]: got 4, want 5") {
+		t.Fatalf("expected a single mismatch at [1 1], got %v", r.errors)
+	}
+}
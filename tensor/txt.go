@@ -0,0 +1,223 @@
+package tensor
+
+import (
+	"bufio"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Options for LoadTxt. The zero value reads comma-separated values without a header, using every column.
+type LoadTxtOptions[T Scalar] struct {
+	// Separator between the values of a row. Defaults to ',' if zero. Use '\t' for TSV.
+	Delimiter rune
+
+	// Lines starting with this character are ignored. Zero disables comments.
+	Comment rune
+
+	// Number of lines to skip at the start, for example 1 to skip a header. Like with numpy.loadtxt(), comments & blank
+	// lines count too.
+	SkipRows int
+
+	// Indices of the columns to read, in the order they should appear in the tensor. All columns are read if nil.
+	Columns []int
+
+	// If true, empty values are replaced with MissingValue instead of causing an error.
+	FillMissing bool
+
+	// Value used in place of empty values when FillMissing is true.
+	MissingValue T
+}
+
+// Options for SaveTxt. The zero value writes comma-separated values without a header.
+type SaveTxtOptions struct {
+	// Separator between the values of a row. Defaults to ',' if zero. Use '\t' for TSV.
+	Delimiter rune
+
+	// Written as is as the first line if not empty.
+	Header string
+}
+
+// Error returned by LoadTxt when a value could not be parsed or a row doesn't have the expected columns.
+type TxtError struct {
+	// Line number in the input, starting from 1.
+	Line int
+
+	// Index of the column in the input, starting from 0.
+	Column int
+
+	// The value that could not be parsed. Empty if the column is missing.
+	Value string
+
+	Err error
+}
+
+func (e *TxtError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+}
+
+func (e *TxtError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	// Error for an empty value in a row when LoadTxtOptions.FillMissing is false.
+	ErrMissingValue = errors.New("missing value")
+
+	// Error for a column that is not present in a row.
+	ErrMissingColumn = errors.New("missing column")
+)
+
+// Reads delimited text (CSV, TSV, etc.) from r into a 2D tensor of shape rows x columns, like numpy.loadtxt().
+//
+// Parsing errors are reported as *TxtError with the line & column of the offending value.
+func LoadTxt[T Scalar](r io.Reader, options ...LoadTxtOptions[T]) (*Tensor[T], error) {
+	if len(options) > 1 {
+		panic("Only one LoadTxtOptions is allowed!")
+	}
+
+	var opts LoadTxtOptions[T]
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	// the skipped lines are read as they are, so that they're never parsed
+	buffered := bufio.NewReader(r)
+	numSkipped := 0
+	for ; numSkipped < opts.SkipRows; numSkipped++ {
+		if _, err := buffered.ReadString('\n'); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+	}
+
+	reader := csv.NewReader(buffered)
+	if opts.Delimiter != 0 {
+		reader.Comma = opts.Delimiter
+	}
+
+	reader.Comment = opts.Comment
+	reader.TrimLeadingSpace = true
+
+	// we validate the number of columns ourselves since the unused columns don't matter
+	reader.FieldsPerRecord = -1
+
+	var data []T
+	numRows := 0
+	numCols := len(opts.Columns)
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		// the line in the whole input, not only in what the CSV reader has seen
+		line, _ := reader.FieldPos(0)
+		line += numSkipped
+
+		columns := opts.Columns
+		if columns == nil {
+			// every row must have as many columns as the first one
+			if numRows == 0 {
+				numCols = len(record)
+			} else if len(record) != numCols {
+				return nil, &TxtError{
+					Line:   line,
+					Column: min(len(record), numCols),
+					Err:    fmt.Errorf("expected %d columns, found %d", numCols, len(record)),
+				}
+			}
+		}
+
+		for i := 0; i < numCols; i++ {
+			column := i
+			if columns != nil {
+				column = columns[i]
+			}
+
+			if column < 0 || column >= len(record) {
+				return nil, &TxtError{Line: line, Column: column, Err: ErrMissingColumn}
+			}
+
+			value := strings.TrimSpace(record[column])
+			if value == "" {
+				if !opts.FillMissing {
+					return nil, &TxtError{Line: line, Column: column, Err: ErrMissingValue}
+				}
+
+				data = append(data, opts.MissingValue)
+				continue
+			}
+
+			parsed, err := parseScalar[T](value)
+			if err != nil {
+				return nil, &TxtError{Line: line, Column: column, Value: value, Err: err}
+			}
+
+			data = append(data, parsed)
+		}
+
+		numRows++
+	}
+
+	if numRows == 0 || numCols == 0 {
+		return nil, fmt.Errorf("%s no values were found", ErrorEmptyArraySlice)
+	}
+
+	return fromData([]uint{uint(numRows), uint(numCols)}, data), nil
+}
+
+// Writes a 1D or 2D tensor to w as delimited text, like numpy.savetxt(). A 1D tensor is written as one value per line.
+func SaveTxt[T Scalar](w io.Writer, t *Tensor[T], options ...SaveTxtOptions) error {
+	if len(options) > 1 {
+		panic("Only one SaveTxtOptions is allowed!")
+	}
+
+	var opts SaveTxtOptions
+	if len(options) > 0 {
+		opts = options[0]
+	}
+
+	var numRows, numCols int
+	switch t.NDims() {
+	case 1:
+		numRows, numCols = int(t.shape[0]), 1
+	case 2:
+		numRows, numCols = int(t.shape[0]), int(t.shape[1])
+	default:
+		return fmt.Errorf("SaveTxt(): expected a 1D or 2D tensor, got shape %v", t.shape)
+	}
+
+	if opts.Header != "" {
+		if _, err := io.WriteString(w, opts.Header+"\n"); err != nil {
+			return err
+		}
+	}
+
+	writer := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		writer.Comma = opts.Delimiter
+	}
+
+	data := t.rowMajor().data
+	record := make([]string, numCols)
+	for r := 0; r < numRows; r++ {
+		for c := 0; c < numCols; c++ {
+			record[c] = formatScalar(data[r*numCols+c])
+		}
+
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,90 @@
+package tensor
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadTxt(t *testing.T) {
+	input := "id,x,y,label\n1, 0.5, -2, 1\n# a comment\n2, 1.5, , 0\n3, -4, 8, 1\n"
+
+	result, err := LoadTxt(strings.NewReader(input), LoadTxtOptions[float64]{
+		Comment:      '#',
+		SkipRows:     1,
+		Columns:      []int{1, 2},
+		FillMissing:  true,
+		MissingValue: -1,
+	})
+	if err != nil {
+		t.Fatalf("LoadTxt(): unexpected error %v", err)
+	}
+
+	expected := WithValue[float64]([][]float64{
+		{0.5, -2},
+		{1.5, -1},
+		{-4, 8},
+	})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+
+	// like with numpy.loadtxt(), the skipped rows are lines, including comments
+	result, err = LoadTxt(strings.NewReader("# generated\nx,y\n1,2\n"), LoadTxtOptions[float64]{Comment: '#', SkipRows: 2})
+	if err != nil {
+		t.Fatalf("LoadTxt(): unexpected error %v", err)
+	}
+
+	expected = WithValue[float64]([][]float64{{1, 2}})
+	if !reflect.DeepEqual(expected, result) {
+		t.Fatalf("expected %v, got %v", expected, result)
+	}
+}
+
+func TestLoadTxtErrors(t *testing.T) {
+	_, err := LoadTxt[int](strings.NewReader("1\t2\n3\tx\n"), LoadTxtOptions[int]{Delimiter: '\t'})
+
+	var txtErr *TxtError
+	if !errors.As(err, &txtErr) {
+		t.Fatalf("LoadTxt(): expected a *TxtError, got %v", err)
+	}
+
+	if txtErr.Line != 2 || txtErr.Column != 1 || txtErr.Value != "x" {
+		t.Fatalf("expected line 2, column 1 & value \"x\", got %v", txtErr)
+	}
+
+	// the line counts the skipped lines too
+	_, err = LoadTxt[int](strings.NewReader("a,b\n1,2\n3,x\n"), LoadTxtOptions[int]{SkipRows: 1})
+	if !errors.As(err, &txtErr) || txtErr.Line != 3 {
+		t.Fatalf("LoadTxt(): expected an error on line 3, got %v", err)
+	}
+
+	_, err = LoadTxt[int](strings.NewReader("1,2\n3,\n"))
+	if !errors.Is(err, ErrMissingValue) {
+		t.Fatalf("LoadTxt(): expected ErrMissingValue, got %v", err)
+	}
+}
+
+func TestSaveTxt(t *testing.T) {
+	tensor := WithValue[float32]([][]float32{{1, 0.25}, {-3.5, 100}})
+
+	var sb strings.Builder
+	if err := SaveTxt(&sb, tensor, SaveTxtOptions{Delimiter: '\t', Header: "a\tb"}); err != nil {
+		t.Fatalf("SaveTxt(): unexpected error %v", err)
+	}
+
+	expected := "a\tb\n1\t0.25\n-3.5\t100\n"
+	if sb.String() != expected {
+		t.Fatalf("expected %q, got %q", expected, sb.String())
+	}
+
+	loaded, err := LoadTxt(strings.NewReader(sb.String()), LoadTxtOptions[float32]{Delimiter: '\t', SkipRows: 1})
+	if err != nil {
+		t.Fatalf("LoadTxt(): unexpected error %v", err)
+	}
+
+	if !reflect.DeepEqual(tensor, loaded) {
+		t.Fatalf("expected %v, got %v", tensor, loaded)
+	}
+}